@@ -0,0 +1,328 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/storage/memory"
+	"github.com/steveyegge/vc/internal/storage/sqlite"
+	"github.com/steveyegge/vc/internal/types"
+)
+
+var _ IssueStore = (*memory.Storage)(nil)
+
+// issueStoreBackends opens each IssueStore implementation with the "vc" prefix
+var issueStoreBackends = []struct {
+	name string
+	open func(t *testing.T) IssueStore
+}{
+	{"sqlite", func(t *testing.T) IssueStore {
+		store, err := sqlite.New(filepath.Join(t.TempDir(), "vc.db"))
+		if err != nil {
+			t.Fatalf("sqlite.New failed: %v", err)
+		}
+		return store
+	}},
+	{"memory", func(t *testing.T) IssueStore {
+		return memory.New("vc")
+	}},
+}
+
+// TestIssueStoreConformance runs the same checks against every backend so their
+// behavior stays identical
+func TestIssueStoreConformance(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store IssueStore)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"Validation", testCreateValidation},
+		{"Update", testUpdate},
+		{"Close", testCloseIssue},
+		{"Search", testSearch},
+		{"Events", testEvents},
+	}
+	for _, backend := range issueStoreBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					store := backend.open(t)
+					defer func() { _ = store.Close() }()
+					tt.run(t, store)
+				})
+			}
+		})
+	}
+}
+
+func newConformanceIssue(title string, priority int) *types.Issue {
+	return &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+}
+
+func testCreateAndGet(t *testing.T, store IssueStore) {
+	ctx := context.Background()
+
+	first := newConformanceIssue("First", 1)
+	second := newConformanceIssue("Second", 2)
+	for _, issue := range []*types.Issue{first, second} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if first.ID != "vc-1" || second.ID != "vc-2" {
+		t.Fatalf("IDs = %s, %s, want vc-1, vc-2", first.ID, second.ID)
+	}
+	if first.CreatedAt.IsZero() || first.UpdatedAt.IsZero() {
+		t.Error("expected timestamps to be set")
+	}
+
+	// Numbering continues after an explicit ID
+	explicit := newConformanceIssue("Explicit", 2)
+	explicit.ID = "vc-10"
+	if err := store.CreateIssue(ctx, explicit, "alice"); err != nil {
+		t.Fatalf("CreateIssue with ID failed: %v", err)
+	}
+	next := newConformanceIssue("Next", 2)
+	if err := store.CreateIssue(ctx, next, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if next.ID != "vc-11" {
+		t.Errorf("ID after explicit vc-10 = %s, want vc-11", next.ID)
+	}
+
+	dup := newConformanceIssue("Duplicate", 2)
+	dup.ID = "vc-10"
+	if err := store.CreateIssue(ctx, dup, "alice"); err == nil {
+		t.Error("expected duplicate ID to fail")
+	}
+
+	got, err := store.GetIssue(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got == nil || got.Title != "First" || got.Priority != 1 || got.Status != types.StatusOpen {
+		t.Fatalf("GetIssue = %+v", got)
+	}
+
+	missing, err := store.GetIssue(ctx, "vc-999")
+	if err != nil || missing != nil {
+		t.Errorf("GetIssue(missing) = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func testCreateValidation(t *testing.T, store IssueStore) {
+	ctx := context.Background()
+
+	for name, issue := range map[string]*types.Issue{
+		"empty title":    newConformanceIssue("", 1),
+		"bad priority":   newConformanceIssue("Bad priority", 9),
+		"bad status":     {Title: "Bad status", Status: "bogus", Priority: 1, IssueType: types.TypeTask},
+		"bad issue type": {Title: "Bad type", Status: types.StatusOpen, Priority: 1, IssueType: "bogus"},
+	} {
+		err := store.CreateIssue(ctx, issue, "alice")
+		if err == nil || !strings.Contains(err.Error(), "validation failed") {
+			t.Errorf("%s: CreateIssue error = %v, want validation failure", name, err)
+		}
+	}
+}
+
+func testUpdate(t *testing.T, store IssueStore) {
+	ctx := context.Background()
+
+	issue := newConformanceIssue("Original", 2)
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{
+		"title":             "Renamed",
+		"priority":          0,
+		"estimated_minutes": 30,
+	}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Title != "Renamed" || got.Priority != 0 || got.EstimatedMinutes == nil || *got.EstimatedMinutes != 30 {
+		t.Errorf("after update: %+v", got)
+	}
+	if !got.UpdatedAt.After(issue.UpdatedAt) && !got.UpdatedAt.Equal(issue.UpdatedAt) {
+		t.Error("expected updated_at to move forward")
+	}
+
+	// No-op updates record no event
+	before, _ := store.GetEvents(ctx, issue.ID, 0)
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "bob"); err != nil {
+		t.Fatalf("no-op UpdateIssue failed: %v", err)
+	}
+	after, _ := store.GetEvents(ctx, issue.ID, 0)
+	if len(after) != len(before) {
+		t.Errorf("no-op update recorded an event: %d -> %d", len(before), len(after))
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"id": "vc-99"}, "bob"); err == nil {
+		t.Error("expected update of a disallowed field to fail")
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 9}, "bob"); err == nil {
+		t.Error("expected invalid priority to fail")
+	}
+	if err := store.UpdateIssue(ctx, "vc-999", map[string]interface{}{"title": "x"}, "bob"); err == nil {
+		t.Error("expected update of a missing issue to fail")
+	}
+}
+
+func testCloseIssue(t *testing.T, store IssueStore) {
+	ctx := context.Background()
+
+	issue := newConformanceIssue("To close", 2)
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusClosed || got.ClosedAt == nil {
+		t.Errorf("after close: status=%s closed_at=%v", got.Status, got.ClosedAt)
+	}
+
+	events, _ := store.GetEvents(ctx, issue.ID, 0)
+	closed := eventsByType(events)[types.EventClosed]
+	if closed == nil || closed.Comment == nil || *closed.Comment != "done" || closed.Actor != "bob" {
+		t.Errorf("close event = %+v", closed)
+	}
+
+	if err := store.CloseIssue(ctx, "vc-999", "done", "bob"); err == nil {
+		t.Error("expected closing a missing issue to fail")
+	}
+}
+
+func testSearch(t *testing.T, store IssueStore) {
+	ctx := context.Background()
+
+	create := func(title string, priority int, status types.Status, assignee string) *types.Issue {
+		issue := newConformanceIssue(title, priority)
+		issue.Assignee = assignee
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if status != types.StatusOpen {
+			if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": status}, "alice"); err != nil {
+				t.Fatalf("UpdateIssue failed: %v", err)
+			}
+		}
+		return issue
+	}
+	low := create("Fix login bug", 3, types.StatusOpen, "")
+	high := create("Fix LOGIN crash", 0, types.StatusInProgress, "bob")
+	other := create("Write docs", 1, types.StatusOpen, "bob")
+
+	ids := func(issues []*types.Issue) string {
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	search := func(query string, filter types.IssueFilter) string {
+		t.Helper()
+		results, err := store.SearchIssues(ctx, query, filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return ids(results)
+	}
+
+	inProgress := types.StatusInProgress
+	bob := "bob"
+	for _, tc := range []struct {
+		name   string
+		query  string
+		filter types.IssueFilter
+		want   []*types.Issue
+	}{
+		{"all by priority", "", types.IssueFilter{}, []*types.Issue{high, other, low}},
+		{"case-insensitive query", "login", types.IssueFilter{}, []*types.Issue{high, low}},
+		{"query matches ID", other.ID, types.IssueFilter{}, []*types.Issue{other}},
+		{"status", "", types.IssueFilter{Status: &inProgress}, []*types.Issue{high}},
+		{"assignee", "", types.IssueFilter{Assignee: &bob}, []*types.Issue{high, other}},
+		{"priorities", "", types.IssueFilter{Priorities: []int{1, 3}}, []*types.Issue{other, low}},
+		{"limit and offset", "", types.IssueFilter{Limit: 1, Offset: 1}, []*types.Issue{other}},
+	} {
+		if got, want := search(tc.query, tc.filter), ids(tc.want); got != want {
+			t.Errorf("%s: got [%s], want [%s]", tc.name, got, want)
+		}
+	}
+}
+
+func testEvents(t *testing.T, store IssueStore) {
+	ctx := context.Background()
+
+	issue := newConformanceIssue("Evented", 2)
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": types.StatusInProgress}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "progress"}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": "dave"}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "carol", "looks good"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	// SQLite timestamps events to the second, so events recorded together have no
+	// defined order; check them by type
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5", len(events))
+	}
+	byType := eventsByType(events)
+
+	if c := byType[types.EventCommented]; c == nil || c.Actor != "carol" || c.Comment == nil || *c.Comment != "looks good" {
+		t.Errorf("comment event = %+v", c)
+	}
+	if e := byType[types.EventStatusChanged]; e == nil || e.Actor != "bob" {
+		t.Errorf("status event = %+v", e)
+	}
+	var changed map[string]interface{}
+	if e := byType[types.EventUpdated]; e == nil || e.NewValue == nil ||
+		json.Unmarshal([]byte(*e.NewValue), &changed) != nil || changed["notes"] != "progress" {
+		t.Errorf("update event = %+v", e)
+	}
+	var assigned map[string]interface{}
+	if e := byType[types.EventAssigned]; e == nil || e.NewValue == nil ||
+		json.Unmarshal([]byte(*e.NewValue), &assigned) != nil || assigned["assignee"] != "dave" {
+		t.Errorf("assigned event = %+v", e)
+	}
+	var created types.Issue
+	if e := byType[types.EventCreated]; e == nil || e.NewValue == nil ||
+		json.Unmarshal([]byte(*e.NewValue), &created) != nil || created.Title != "Evented" {
+		t.Errorf("created event = %+v", e)
+	}
+
+	limited, _ := store.GetEvents(ctx, issue.ID, 2)
+	if len(limited) != 2 {
+		t.Errorf("GetEvents(limit 2) = %d events", len(limited))
+	}
+}
+
+// eventsByType indexes events by type, keeping the first of each
+func eventsByType(events []*types.Event) map[types.EventType]*types.Event {
+	byType := make(map[types.EventType]*types.Event)
+	for _, event := range events {
+		if _, ok := byType[event.EventType]; !ok {
+			byType[event.EventType] = event
+		}
+	}
+	return byType
+}
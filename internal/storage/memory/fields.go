@@ -0,0 +1,201 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// issueField returns a pointer to the issue field updated under a column name, for
+// the same fields the SQLite backend lets UpdateIssue change. The SQLite-only
+// approval columns have no Issue field and are not supported.
+func issueField(issue *types.Issue, field string) (interface{}, bool) {
+	switch field {
+	case "status":
+		return &issue.Status, true
+	case "priority":
+		return &issue.Priority, true
+	case "title":
+		return &issue.Title, true
+	case "assignee":
+		return &issue.Assignee, true
+	case "description":
+		return &issue.Description, true
+	case "design":
+		return &issue.Design, true
+	case "acceptance_criteria":
+		return &issue.AcceptanceCriteria, true
+	case "notes":
+		return &issue.Notes, true
+	case "issue_type":
+		return &issue.IssueType, true
+	case "estimated_minutes":
+		return &issue.EstimatedMinutes, true
+	case "estimate_min_minutes":
+		return &issue.EstimateMinMinutes, true
+	case "estimate_max_minutes":
+		return &issue.EstimateMaxMinutes, true
+	case "reviewer":
+		return &issue.Reviewer, true
+	case "due_at":
+		return &issue.DueAt, true
+	case "approved_at":
+		return &issue.ApprovedAt, true
+	case "approved_by":
+		return &issue.ApprovedBy, true
+	}
+	return nil, false
+}
+
+// setField assigns an update value to a field returned by issueField, accepting the
+// types callers pass to the SQLite backend (e.g. types.Status or string, int or *int)
+func setField(target interface{}, value interface{}) error {
+	switch t := target.(type) {
+	case *string:
+		if v, ok := value.(string); ok {
+			*t = v
+			return nil
+		}
+	case *types.Status:
+		switch v := value.(type) {
+		case string:
+			*t = types.Status(v)
+			return nil
+		case types.Status:
+			*t = v
+			return nil
+		}
+	case *types.IssueType:
+		switch v := value.(type) {
+		case string:
+			*t = types.IssueType(v)
+			return nil
+		case types.IssueType:
+			*t = v
+			return nil
+		}
+	case *int:
+		if v, ok := value.(int); ok {
+			*t = v
+			return nil
+		}
+	case **int:
+		switch v := value.(type) {
+		case nil:
+			*t = nil
+			return nil
+		case int:
+			*t = &v
+			return nil
+		case *int:
+			if v != nil {
+				n := *v
+				v = &n
+			}
+			*t = v
+			return nil
+		}
+	case **time.Time:
+		switch v := value.(type) {
+		case nil:
+			*t = nil
+			return nil
+		case time.Time:
+			*t = &v
+			return nil
+		case *time.Time:
+			if v != nil {
+				tm := *v
+				v = &tm
+			}
+			*t = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported type %T", value)
+}
+
+// matchesFilter reports whether an issue passes the filter's field conditions
+func matchesFilter(issue *types.Issue, filter types.IssueFilter) bool {
+	if issue.ArchivedAt != nil && !filter.IncludeArchived {
+		return false
+	}
+
+	statuses := filter.Statuses
+	if filter.Status != nil {
+		statuses = append(statuses[:len(statuses):len(statuses)], *filter.Status)
+	}
+	if len(statuses) > 0 && !contains(statuses, issue.Status) {
+		return false
+	}
+
+	priorities := filter.Priorities
+	if filter.Priority != nil {
+		priorities = append(priorities[:len(priorities):len(priorities)], *filter.Priority)
+	}
+	if len(priorities) > 0 && !contains(priorities, issue.Priority) {
+		return false
+	}
+
+	issueTypes := filter.IssueTypes
+	if filter.IssueType != nil {
+		issueTypes = append(issueTypes[:len(issueTypes):len(issueTypes)], *filter.IssueType)
+	}
+	if len(issueTypes) > 0 && !contains(issueTypes, issue.IssueType) {
+		return false
+	}
+
+	if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
+		return false
+	}
+	if filter.Reviewer != nil && issue.Reviewer != *filter.Reviewer {
+		return false
+	}
+	if filter.Blocked != nil && (issue.Status == types.StatusBlocked) != *filter.Blocked {
+		return false
+	}
+	if filter.Approved != nil && (issue.ApprovedAt != nil) != *filter.Approved {
+		return false
+	}
+	// This store has no dependencies, so NoOpenBlockers matches every issue and
+	// every issue is top-level
+	if filter.ParentID != nil && *filter.ParentID != "" {
+		return false
+	}
+	if filter.Overdue && (issue.Status == types.StatusClosed || issue.DueAt == nil || !issue.DueAt.Before(time.Now())) {
+		return false
+	}
+
+	for _, bound := range []struct {
+		value  time.Time
+		limit  *time.Time
+		before bool
+	}{
+		{issue.CreatedAt, filter.CreatedAfter, false},
+		{issue.CreatedAt, filter.CreatedBefore, true},
+		{issue.UpdatedAt, filter.UpdatedAfter, false},
+		{issue.UpdatedAt, filter.UpdatedBefore, true},
+	} {
+		if bound.limit == nil {
+			continue
+		}
+		if bound.before && bound.value.After(*bound.limit) {
+			return false
+		}
+		if !bound.before && bound.value.Before(*bound.limit) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains reports whether values includes v
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
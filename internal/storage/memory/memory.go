@@ -0,0 +1,356 @@
+// Package memory is an in-memory issue store for fast unit tests. It implements
+// storage.IssueStore with the same semantics as the SQLite backend (ID generation,
+// validation, change detection and event recording) without touching disk or the
+// sqlite driver. Data lives only as long as the Storage value.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// Storage is an in-memory issue store. It is safe for concurrent use.
+type Storage struct {
+	mu          sync.Mutex
+	prefix      string                  // ID prefix without the dash (e.g. "vc")
+	lastID      int                     // Highest issue number used with prefix
+	issues      map[string]*types.Issue // Keyed by ID
+	events      []*types.Event          // In insertion order
+	nextEventID int64
+	closed      bool
+}
+
+// New creates an empty store whose generated IDs use prefix (e.g. "vc" gives "vc-1").
+// A trailing dash is accepted.
+func New(prefix string) *Storage {
+	return &Storage{
+		prefix: strings.TrimSuffix(prefix, "-"),
+		issues: make(map[string]*types.Issue),
+	}
+}
+
+// CreateIssue validates and stores a new issue, generating its ID if it has none,
+// and records a creation event
+func (s *Storage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if err := issue.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+
+	if issue.ID == "" {
+		s.lastID++
+		issue.ID = fmt.Sprintf("%s-%d", s.prefix, s.lastID)
+	} else if _, exists := s.issues[issue.ID]; exists {
+		return fmt.Errorf("failed to insert issue: issue %s already exists", issue.ID)
+	} else if n, ok := s.issueNumber(issue.ID); ok && n > s.lastID {
+		// Like the SQLite counter, numbering continues after explicit IDs
+		s.lastID = n
+	}
+
+	now := time.Now()
+	issue.CreatedAt = now
+	issue.UpdatedAt = now
+	stored := cloneIssue(issue)
+	s.issues[issue.ID] = stored
+
+	eventData, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	s.recordEvent(issue.ID, types.EventCreated, actor, nil, eventData, nil, now)
+	return nil
+}
+
+// GetIssue returns a copy of the issue, or nil if it doesn't exist
+func (s *Storage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errClosed
+	}
+
+	issue, ok := s.issues[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneIssue(issue), nil
+}
+
+// UpdateIssue updates fields on an issue, keyed by column name as in the SQLite
+// backend. Updates matching the current values are dropped; if nothing changes,
+// no event is recorded.
+func (s *Storage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+
+	old, ok := s.issues[id]
+	if !ok {
+		return fmt.Errorf("issue %s not found", id)
+	}
+
+	updated := cloneIssue(old)
+	changed := make(map[string]interface{}, len(updates))
+	for field, value := range updates {
+		target, ok := issueField(updated, field)
+		if !ok {
+			return types.NewValidationError(field, value, types.ErrInvalidField, "invalid field for update: %s", field)
+		}
+		before := reflect.ValueOf(target).Elem().Interface()
+		if err := setField(target, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", field, err)
+		}
+		if !reflect.DeepEqual(before, reflect.ValueOf(target).Elem().Interface()) {
+			changed[field] = value
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	// Leaving blocked status drops the blocked reason
+	if _, ok := changed["status"]; ok && updated.Status != types.StatusBlocked {
+		updated.BlockedReason = ""
+	}
+
+	// An assignee change gets its own EventAssigned, as in the SQLite backend
+	var assignedOld, assignedNew []byte
+	if assignee, ok := changed["assignee"]; ok {
+		delete(changed, "assignee")
+		var err error
+		if assignedOld, err = json.Marshal(map[string]interface{}{"assignee": old.Assignee}); err != nil {
+			return fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		if assignedNew, err = json.Marshal(map[string]interface{}{"assignee": assignee}); err != nil {
+			return fmt.Errorf("failed to marshal event data: %w", err)
+		}
+	}
+	oldData, err := json.Marshal(old)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	newData, err := json.Marshal(changed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	now := time.Now()
+	updated.UpdatedAt = now
+	s.issues[id] = updated
+
+	if len(changed) > 0 {
+		eventType := types.EventUpdated
+		if _, ok := changed["status"]; ok {
+			if updated.Status == types.StatusClosed {
+				eventType = types.EventClosed
+			} else {
+				eventType = types.EventStatusChanged
+			}
+		}
+		s.recordEvent(id, eventType, actor, oldData, newData, nil, now)
+	}
+	if assignedNew != nil {
+		s.recordEvent(id, types.EventAssigned, actor, assignedOld, assignedNew, nil, now)
+	}
+	return nil
+}
+
+// CloseIssue closes an issue, setting closed_at, and records reason on the close event
+func (s *Storage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+
+	issue, ok := s.issues[id]
+	if !ok {
+		return fmt.Errorf("issue %s not found", id)
+	}
+
+	now := time.Now()
+	issue.Status = types.StatusClosed
+	issue.ClosedAt = &now
+	issue.UpdatedAt = now
+	issue.BlockedReason = ""
+	s.recordEvent(id, types.EventClosed, actor, nil, nil, &reason, now)
+	return nil
+}
+
+// SearchIssues returns issues whose title, description or ID contains query (case
+// insensitively, like SQL LIKE), filtered by filter, pinned issues first and then
+// by priority and newest first. Filters that need data this store doesn't keep
+// (labels, flags, custom sorts and cursors) return an error rather than being ignored.
+func (s *Storage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	if len(filter.Labels) > 0 || filter.HasFlag != nil || filter.SortBy != "" || filter.Cursor != "" {
+		return nil, fmt.Errorf("filter not supported by the memory backend")
+	}
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errClosed
+	}
+
+	query = strings.ToLower(query)
+	var results []*types.Issue
+	for _, issue := range s.issues {
+		if query != "" &&
+			!strings.Contains(strings.ToLower(issue.Title), query) &&
+			!strings.Contains(strings.ToLower(issue.Description), query) &&
+			!strings.Contains(strings.ToLower(issue.ID), query) {
+			continue
+		}
+		if matchesFilter(issue, filter) {
+			results = append(results, cloneIssue(issue))
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+
+	if filter.Offset >= len(results) {
+		return nil, nil
+	}
+	results = results[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(results) {
+		results = results[:filter.Limit]
+	}
+	return results, nil
+}
+
+// AddComment records a comment event on an issue
+func (s *Storage) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	now := time.Now()
+	issue.UpdatedAt = now
+	s.recordEvent(issueID, types.EventCommented, actor, nil, nil, &comment, now)
+	return nil
+}
+
+// GetEvents returns an issue's events, newest first, up to limit (0 for all)
+func (s *Storage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errClosed
+	}
+
+	var events []*types.Event
+	for i := len(s.events) - 1; i >= 0; i-- {
+		if s.events[i].IssueID != issueID {
+			continue
+		}
+		event := *s.events[i]
+		events = append(events, &event)
+		if limit > 0 && len(events) == limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+// Close releases the store; later calls fail
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.issues = nil
+	s.events = nil
+	return nil
+}
+
+var errClosed = fmt.Errorf("storage is closed")
+
+// recordEvent appends an event; callers hold s.mu
+func (s *Storage) recordEvent(issueID string, eventType types.EventType, actor string, oldValue, newValue []byte, comment *string, at time.Time) {
+	s.nextEventID++
+	event := &types.Event{
+		ID:        s.nextEventID,
+		IssueID:   issueID,
+		EventType: eventType,
+		Actor:     actor,
+		Comment:   comment,
+		CreatedAt: at,
+	}
+	if oldValue != nil {
+		v := string(oldValue)
+		event.OldValue = &v
+	}
+	if newValue != nil {
+		v := string(newValue)
+		event.NewValue = &v
+	}
+	s.events = append(s.events, event)
+}
+
+// issueNumber parses the number of an ID with the store's prefix
+func (s *Storage) issueNumber(id string) (int, bool) {
+	rest, ok := strings.CutPrefix(id, s.prefix+"-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(rest)
+	return n, err == nil
+}
+
+// cloneIssue copies an issue, including the values behind its pointer fields, so
+// callers can't modify stored issues
+func cloneIssue(issue *types.Issue) *types.Issue {
+	c := *issue
+	for _, p := range []**int{&c.EstimatedMinutes, &c.EstimateMinMinutes, &c.EstimateMaxMinutes} {
+		if *p != nil {
+			v := **p
+			*p = &v
+		}
+	}
+	for _, p := range []**time.Time{&c.ClosedAt, &c.DueAt, &c.ArchivedAt, &c.ApprovedAt} {
+		if *p != nil {
+			t := **p
+			*p = &t
+		}
+	}
+	return &c
+}
@@ -13,6 +13,10 @@ type Migration struct {
 	Description string
 	Up          string // SQL to apply the migration
 	Down        string // SQL to revert the migration
+
+	// UpFunc applies the migration instead of Up when set, for changes that need
+	// to inspect the database first, such as adding a column only if it's missing
+	UpFunc func(tx *sql.Tx) error
 }
 
 // Manager handles database migrations
@@ -127,7 +131,11 @@ func applySQLiteMigration(db *sql.DB, migration Migration) error {
 	defer func() { _ = tx.Rollback() }()
 
 	// Execute migration SQL
-	if _, err := tx.Exec(migration.Up); err != nil {
+	if migration.UpFunc != nil {
+		if err := migration.UpFunc(tx); err != nil {
+			return fmt.Errorf("failed to execute migration: %w", err)
+		}
+	} else if _, err := tx.Exec(migration.Up); err != nil {
 		return fmt.Errorf("failed to execute migration SQL: %w", err)
 	}
 
@@ -98,3 +98,37 @@ func TestMigrationOrdering(t *testing.T) {
 		t.Errorf("expected third migration version 3, got %d", manager.migrations[2].Version)
 	}
 }
+
+func TestSQLiteMigrationUpFunc(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	calls := 0
+	manager := NewManager()
+	manager.Register(Migration{
+		Version:     1,
+		Description: "Go migration",
+		Up:          "THIS IS NOT SQL",
+		UpFunc: func(tx *sql.Tx) error {
+			calls++
+			_, err := tx.Exec("CREATE TABLE func_table (id INTEGER PRIMARY KEY)")
+			return err
+		},
+	})
+
+	if err := manager.ApplySQLite(db); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+	if err := manager.ApplySQLite(db); err != nil {
+		t.Fatalf("failed to reapply migrations: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected UpFunc to run once, ran %d times", calls)
+	}
+	if _, err := db.Exec("INSERT INTO func_table (id) VALUES (1)"); err != nil {
+		t.Errorf("UpFunc table not created: %v", err)
+	}
+}
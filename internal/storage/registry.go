@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/steveyegge/vc/internal/storage/sqlite"
+)
+
+// Compile-time checks that the SQLite backend satisfies the Storage interface and
+// every feature interface
+var (
+	_ Storage         = (*sqlite.SQLiteStorage)(nil)
+	_ ExtendedStorage = (*sqlite.SQLiteStorage)(nil)
+)
+
+// Option configures a backend opened through Open.
+// Each driver defines which option values it accepts and rejects the rest,
+// e.g. the "sqlite" driver accepts sqlite.Option values.
+type Option interface{}
+
+// Driver opens a Storage backend for a data source name (e.g. a file path or URL)
+type Driver func(dsn string, opts ...Option) (Storage, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Driver)
+)
+
+func init() {
+	Register("sqlite", openSQLite)
+}
+
+// Register makes a storage backend available under the given name.
+// Like database/sql, it panics if the driver is nil or registered twice.
+func Register(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if driver == nil {
+		panic("storage: Register driver is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("storage: Register called twice for driver " + name)
+	}
+	drivers[name] = driver
+}
+
+// Drivers returns the sorted names of the registered backends
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open opens a storage backend by driver name, decoupling callers from the
+// concrete implementation
+func Open(driver, dsn string, opts ...Option) (Storage, error) {
+	driversMu.RLock()
+	open, ok := drivers[driver]
+	driversMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown storage driver %q (registered: %v)", driver, Drivers())
+	}
+	return open(dsn, opts...)
+}
+
+// openSQLite is the Driver for the built-in SQLite backend
+func openSQLite(dsn string, opts ...Option) (Storage, error) {
+	sqliteOpts := make([]sqlite.Option, 0, len(opts))
+	for _, opt := range opts {
+		o, ok := opt.(sqlite.Option)
+		if !ok {
+			return nil, fmt.Errorf("sqlite driver: unsupported option type %T", opt)
+		}
+		sqliteOpts = append(sqliteOpts, o)
+	}
+	return sqlite.New(dsn, sqliteOpts...)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/storage/sqlite"
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestOpenSQLiteDriver(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "vc.db")
+
+	store, err := Open("sqlite", dbPath, sqlite.WithAssigneeRequired(types.StatusInProgress))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	issue := &types.Issue{Title: "Opened via registry", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+}
+
+func TestOpenRejectsUnknownDriverAndOptions(t *testing.T) {
+	if _, err := Open("postgres", "postgres://localhost/vc"); err == nil {
+		t.Error("Expected error for unregistered driver")
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "vc.db")
+	if _, err := Open("sqlite", dbPath, "not-an-option"); err == nil {
+		t.Error("Expected error for unsupported option type")
+	}
+}
+
+func TestRegisterDriver(t *testing.T) {
+	called := false
+	Register("test-backend", func(dsn string, opts ...Option) (Storage, error) {
+		called = true
+		return nil, nil
+	})
+	defer func() {
+		driversMu.Lock()
+		delete(drivers, "test-backend")
+		driversMu.Unlock()
+	}()
+
+	if _, err := Open("test-backend", "anything"); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !called {
+		t.Error("Expected registered driver to be invoked")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on duplicate registration")
+		}
+	}()
+	Register("sqlite", openSQLite)
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// AddIDAlias makes alias another name for an issue, so references to an ID the
+// issue had elsewhere (e.g. in a tracker it was migrated from) keep resolving
+// through GetIssue and ResolveID. Aliases are unique: an alias that already names
+// an issue, as an alias or as an issue ID, fails with ErrAliasInUse, unless it
+// already names this issue. Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) AddIDAlias(ctx context.Context, issueID, alias string) error {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return types.NewValidationError("alias", alias, types.ErrInvalidField, "alias cannot be empty")
+	}
+	found, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+	if alias == issueID {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var taken int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, alias).Scan(&taken)
+	if err == nil {
+		return fmt.Errorf("alias %s is an issue ID: %w", alias, ErrAliasInUse)
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check alias: %w", err)
+	}
+
+	var owner string
+	err = tx.QueryRowContext(ctx, `SELECT issue_id FROM id_aliases WHERE alias = ?`, alias).Scan(&owner)
+	switch {
+	case err == nil && owner == issueID:
+		return nil
+	case err == nil:
+		return fmt.Errorf("alias %s names %s: %w", alias, owner, ErrAliasInUse)
+	case err != sql.ErrNoRows:
+		return fmt.Errorf("failed to check alias: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO id_aliases (alias, issue_id, created_at) VALUES (?, ?, ?)
+	`, alias, issueID, s.now()); err != nil {
+		return fmt.Errorf("failed to add alias: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetIDAliases returns an issue's aliases in alphabetical order. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) GetIDAliases(ctx context.Context, issueID string) ([]string, error) {
+	found, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT alias FROM id_aliases WHERE issue_id = ? ORDER BY alias
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var aliases []string
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// resolveAlias returns the ID of the issue visible to the storage that alias names,
+// or "" if there is none
+func (s *SQLiteStorage) resolveAlias(ctx context.Context, alias string) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT i.id FROM id_aliases a JOIN issues i ON i.id = a.issue_id
+		WHERE a.alias = ? AND (? = '' OR i.project_id = ?)
+	`, alias, s.opts.project, s.opts.project).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve alias: %w", err)
+	}
+	return id, nil
+}
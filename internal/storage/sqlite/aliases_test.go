@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestIDAliases(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Migrated from JIRA", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	other := &types.Issue{Title: "Another", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, other} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	for _, alias := range []string{"PROJ-42", "legacy-7"} {
+		if err := store.AddIDAlias(ctx, issue.ID, alias); err != nil {
+			t.Fatalf("AddIDAlias(%s) failed: %v", alias, err)
+		}
+	}
+	// Re-adding an alias to the same issue is a no-op
+	if err := store.AddIDAlias(ctx, issue.ID, "PROJ-42"); err != nil {
+		t.Errorf("Expected re-adding an alias to succeed, got %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, "PROJ-42")
+	if err != nil {
+		t.Fatalf("GetIssue by alias failed: %v", err)
+	}
+	if got == nil || got.ID != issue.ID {
+		t.Fatalf("Expected GetIssue(PROJ-42) to return %s, got %+v", issue.ID, got)
+	}
+	id, err := store.ResolveID(ctx, "legacy-7")
+	if err != nil || id != issue.ID {
+		t.Errorf("ResolveID(legacy-7) = %q, %v, want %s", id, err, issue.ID)
+	}
+	aliases, err := store.GetIDAliases(ctx, issue.ID)
+	if err != nil || len(aliases) != 2 || aliases[0] != "PROJ-42" || aliases[1] != "legacy-7" {
+		t.Errorf("GetIDAliases = %v, %v", aliases, err)
+	}
+
+	// Aliases are unique, and can't shadow an issue ID
+	if err := store.AddIDAlias(ctx, other.ID, "PROJ-42"); !errors.Is(err, ErrAliasInUse) {
+		t.Errorf("Expected ErrAliasInUse for a taken alias, got %v", err)
+	}
+	if err := store.AddIDAlias(ctx, other.ID, issue.ID); !errors.Is(err, ErrAliasInUse) {
+		t.Errorf("Expected ErrAliasInUse for an issue ID, got %v", err)
+	}
+	if err := store.AddIDAlias(ctx, "vc-999", "PROJ-1"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+
+	if got, err := store.GetIssue(ctx, "PROJ-999"); err != nil || got != nil {
+		t.Errorf("Expected unknown alias to find nothing, got %v, %v", got, err)
+	}
+	if _, err := store.ResolveID(ctx, "PROJ-999"); err == nil {
+		t.Error("Expected ResolveID to fail for an unknown alias")
+	}
+}
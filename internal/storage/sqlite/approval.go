@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// ApproveIssue marks an issue approved by approver, setting approved_at to now and
+// approved_by, and records an EventApproved. Approving an approved issue is a no-op
+// that keeps the original approval; unapprove it first to record a new one.
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) ApproveIssue(ctx context.Context, id, approver string) error {
+	if strings.TrimSpace(approver) == "" {
+		return types.NewValidationError("approved_by", approver, types.ErrInvalidField, "approver is required")
+	}
+	return s.setApproved(ctx, id, true, approver)
+}
+
+// UnapproveIssue clears approved_at and approved_by and records an EventUnapproved
+// by actor. Unapproving an issue that isn't approved is a no-op. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) UnapproveIssue(ctx context.Context, id, actor string) error {
+	return s.setApproved(ctx, id, false, actor)
+}
+
+// setApproved approves or unapproves an issue, recording an event if that changed it.
+// When approving, actor is the approver.
+func (s *SQLiteStorage) setApproved(ctx context.Context, id string, approve bool, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var approvedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT approved_at FROM issues WHERE id = ? AND (? = '' OR project_id = ?)
+	`, id, s.opts.project, s.opts.project).Scan(&approvedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if approvedAt.Valid == approve {
+		return nil
+	}
+
+	now := s.now()
+	eventType := types.EventUnapproved
+	at, by := sql.NullTime{}, sql.NullString{}
+	if approve {
+		eventType = types.EventApproved
+		at = sql.NullTime{Time: now, Valid: true}
+		by = sql.NullString{String: actor, Valid: true}
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET approved_at = ?, approved_by = ?, updated_at = ? WHERE id = ?
+	`, at, by, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to update approval: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, created_at)
+		VALUES (?, ?, ?, ?)
+	`, id, eventType, actor, now)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.notifyWatchers(ctx, id, eventType, actor)
+	return nil
+}
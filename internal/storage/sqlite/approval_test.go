@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestApproveIssue(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	clock := now
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Needs sign-off", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	pending := &types.Issue{Title: "Awaiting sign-off", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	for _, i := range []*types.Issue{issue, pending} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.ApproveIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("ApproveIssue failed: %v", err)
+	}
+	// Approving again keeps the original approval
+	clock = now.Add(time.Hour)
+	if err := store.ApproveIssue(ctx, issue.ID, "bob"); err != nil {
+		t.Fatalf("ApproveIssue of approved issue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.ApprovedAt == nil || !got.ApprovedAt.Equal(now) || got.ApprovedBy != "alice" {
+		t.Errorf("Expected approval by alice at %v, got %v by %q", now, got.ApprovedAt, got.ApprovedBy)
+	}
+
+	approved, unapproved := true, false
+	search := func(filter types.IssueFilter) []string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "sign-off", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return issueIDs(issues)
+	}
+	if got := search(types.IssueFilter{Approved: &approved}); len(got) != 1 || got[0] != issue.ID {
+		t.Errorf("Expected only %s to be approved, got %v", issue.ID, got)
+	}
+	if got := search(types.IssueFilter{Approved: &unapproved}); len(got) != 1 || got[0] != pending.ID {
+		t.Errorf("Expected only %s to be unapproved, got %v", pending.ID, got)
+	}
+
+	if err := store.UnapproveIssue(ctx, issue.ID, "carol"); err != nil {
+		t.Fatalf("UnapproveIssue failed: %v", err)
+	}
+	// Unapproving again is a no-op
+	if err := store.UnapproveIssue(ctx, issue.ID, "carol"); err != nil {
+		t.Fatalf("UnapproveIssue of unapproved issue failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.ApprovedAt != nil || got.ApprovedBy != "" {
+		t.Errorf("Expected approval to be cleared, got %v by %q", got.ApprovedAt, got.ApprovedBy)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	counts := make(map[types.EventType]int)
+	for _, e := range events {
+		counts[e.EventType]++
+		if e.EventType == types.EventApproved && e.Actor != "alice" {
+			t.Errorf("Expected approval event by alice, got %q", e.Actor)
+		}
+	}
+	if counts[types.EventApproved] != 1 || counts[types.EventUnapproved] != 1 {
+		t.Errorf("Expected one approved and one unapproved event, got %v", counts)
+	}
+
+	if err := store.ApproveIssue(ctx, "vc-9999", "alice"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+	if err := store.ApproveIssue(ctx, issue.ID, " "); !errors.Is(err, types.ErrValidation) {
+		t.Errorf("Expected ErrValidation for an empty approver, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// ArchiveIssue hides an issue from searches and work queues without deleting it,
+// setting archived_at and recording an EventArchived. Archiving is about visibility,
+// not completion: the issue keeps its status, and UnarchiveIssue brings it back.
+// Archiving an archived issue is a no-op. Returns ErrIssueNotFound if the issue
+// doesn't exist.
+func (s *SQLiteStorage) ArchiveIssue(ctx context.Context, id, actor string) error {
+	return s.setArchived(ctx, id, true, actor)
+}
+
+// UnarchiveIssue clears archived_at so the issue shows up in searches again, and
+// records an EventUnarchived. Unarchiving an issue that isn't archived is a no-op.
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) UnarchiveIssue(ctx context.Context, id, actor string) error {
+	return s.setArchived(ctx, id, false, actor)
+}
+
+// setArchived archives or unarchives an issue, recording an event if that changed it
+func (s *SQLiteStorage) setArchived(ctx context.Context, id string, archive bool, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var archivedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT archived_at FROM issues WHERE id = ? AND (? = '' OR project_id = ?)
+	`, id, s.opts.project, s.opts.project).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if archivedAt.Valid == archive {
+		return nil
+	}
+
+	now := s.now()
+	eventType := types.EventUnarchived
+	value := sql.NullTime{}
+	if archive {
+		eventType = types.EventArchived
+		value = sql.NullTime{Time: now, Valid: true}
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET archived_at = ?, updated_at = ? WHERE id = ?
+	`, value, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to update archived state: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, created_at)
+		VALUES (?, ?, ?, ?)
+	`, id, eventType, actor, now)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.notifyWatchers(ctx, id, eventType, actor)
+	return nil
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestArchiveIssue(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Old idea", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	visible := &types.Issue{Title: "Current idea", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	for _, i := range []*types.Issue{issue, visible} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.ArchiveIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+	// Archiving again is a no-op
+	if err := store.ArchiveIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("ArchiveIssue of archived issue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.ArchivedAt == nil || got.Status != types.StatusOpen {
+		t.Errorf("Expected an archived issue that is still open, got archived_at=%v status=%s", got.ArchivedAt, got.Status)
+	}
+
+	ids := func(filter types.IssueFilter) []string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "idea", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var out []string
+		for _, i := range issues {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+	if got := ids(types.IssueFilter{}); len(got) != 1 || got[0] != visible.ID {
+		t.Errorf("Expected only %s by default, got %v", visible.ID, got)
+	}
+	if got := ids(types.IssueFilter{IncludeArchived: true}); len(got) != 2 {
+		t.Errorf("Expected both issues with IncludeArchived, got %v", got)
+	}
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	for _, i := range ready {
+		if i.ID == issue.ID {
+			t.Error("Expected archived issue to be left out of ready work")
+		}
+	}
+
+	if err := store.UnarchiveIssue(ctx, issue.ID, "bob"); err != nil {
+		t.Fatalf("UnarchiveIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.ArchivedAt != nil {
+		t.Errorf("Expected archived_at to be cleared, got %v", got.ArchivedAt)
+	}
+	if got := ids(types.IssueFilter{}); len(got) != 2 {
+		t.Errorf("Expected both issues after unarchiving, got %v", got)
+	}
+
+	events, err := store.GetEventTimeline(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetEventTimeline failed: %v", err)
+	}
+	var archived, unarchived int
+	for _, e := range events {
+		switch e.EventType {
+		case types.EventArchived:
+			archived++
+		case types.EventUnarchived:
+			unarchived++
+		}
+	}
+	if archived != 1 || unarchived != 1 {
+		t.Errorf("Expected one archived and one unarchived event, got %d and %d", archived, unarchived)
+	}
+
+	if err := store.ArchiveIssue(ctx, "vc-999", "alice"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
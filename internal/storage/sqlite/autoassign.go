@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+const (
+	// autoAssignPoolKey lists the auto-assign rotation, comma separated, when
+	// WithAutoAssignPool isn't given
+	autoAssignPoolKey = "auto_assign.pool"
+
+	// autoAssignCursorKey stores the pool index of the next assignee in the rotation
+	autoAssignCursorKey = "auto_assign.cursor"
+)
+
+// CreateIssueAutoAssign creates an issue like CreateIssue, assigning it to the next
+// person in the auto-assign rotation (see WithAutoAssignPool). People at their WIP
+// limit are skipped; if everyone is, the issue is created unassigned. An issue that
+// already has an assignee keeps it and doesn't advance the rotation. The rotation
+// cursor is stored in the config table, so it survives restarts.
+func (s *SQLiteStorage) CreateIssueAutoAssign(ctx context.Context, issue *types.Issue, actor string) error {
+	if issue.Assignee != "" {
+		return s.createIssue(ctx, issue, actor, nil)
+	}
+
+	s.autoAssignMu.Lock()
+	defer s.autoAssignMu.Unlock()
+
+	pool, err := s.autoAssignPool(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pool) == 0 {
+		return fmt.Errorf("auto-assign pool is empty")
+	}
+
+	cursor := 0
+	value, err := s.GetConfig(ctx, autoAssignCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to read auto-assign cursor: %w", err)
+	}
+	if value != "" {
+		if cursor, err = strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid auto-assign cursor: %q", value)
+		}
+	}
+
+	next := -1
+	for i := 0; i < len(pool); i++ {
+		candidate := (cursor + i) % len(pool)
+		err := s.checkWIPLimit(ctx, s.db, "", pool[candidate])
+		if errors.Is(err, ErrWIPLimitExceeded) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		next = candidate
+		break
+	}
+	if next < 0 {
+		return s.createIssue(ctx, issue, actor, nil)
+	}
+
+	issue.Assignee = pool[next]
+	if err := s.createIssue(ctx, issue, actor, nil); err != nil {
+		issue.Assignee = ""
+		return err
+	}
+	if err := s.SetConfig(ctx, autoAssignCursorKey, strconv.Itoa((next+1)%len(pool))); err != nil {
+		return fmt.Errorf("failed to save auto-assign cursor: %w", err)
+	}
+	return nil
+}
+
+// autoAssignPool returns the configured rotation, from WithAutoAssignPool or the config table
+func (s *SQLiteStorage) autoAssignPool(ctx context.Context) ([]string, error) {
+	if len(s.opts.autoAssignPool) > 0 {
+		return s.opts.autoAssignPool, nil
+	}
+	value, err := s.GetConfig(ctx, autoAssignPoolKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auto-assign pool: %w", err)
+	}
+	var pool []string
+	for _, assignee := range strings.Split(value, ",") {
+		if assignee = strings.TrimSpace(assignee); assignee != "" {
+			pool = append(pool, assignee)
+		}
+	}
+	return pool, nil
+}
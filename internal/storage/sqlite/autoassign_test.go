@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestCreateIssueAutoAssign(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "bd.db")
+	store, err := New(path, WithAutoAssignPool("alice", "bob", "carol"), WithWIPLimit(1))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	create := func(store *SQLiteStorage) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: "Triage", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+		if err := store.CreateIssueAutoAssign(ctx, issue, "triage"); err != nil {
+			t.Fatalf("CreateIssueAutoAssign failed: %v", err)
+		}
+		return issue
+	}
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, create(store).Assignee)
+	}
+	want := []string{"alice", "bob", "carol", "alice"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected assignees %v, got %v", want, got)
+		}
+	}
+
+	// An explicit assignee is kept and doesn't advance the rotation
+	explicit := &types.Issue{Title: "Mine", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "dave"}
+	if err := store.CreateIssueAutoAssign(ctx, explicit, "dave"); err != nil {
+		t.Fatalf("CreateIssueAutoAssign failed: %v", err)
+	}
+	if explicit.Assignee != "dave" {
+		t.Errorf("Expected explicit assignee to be kept, got %q", explicit.Assignee)
+	}
+
+	// bob is next, but at his WIP limit
+	busy := &types.Issue{Title: "Busy", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask, Assignee: "bob"}
+	if err := store.CreateIssue(ctx, busy, "bob"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if got := create(store).Assignee; got != "carol" {
+		t.Errorf("Expected bob to be skipped for carol, got %q", got)
+	}
+	_ = store.Close()
+
+	// The cursor survives a restart
+	store, err = New(path, WithAutoAssignPool("alice", "bob", "carol"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if got := create(store).Assignee; got != "alice" {
+		t.Errorf("Expected rotation to resume with alice, got %q", got)
+	}
+}
+
+func TestCreateIssueAutoAssignConfigPool(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Triage", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssueAutoAssign(ctx, issue, "triage"); err == nil {
+		t.Error("Expected an error with no pool configured")
+	}
+
+	if err := store.SetConfig(ctx, "auto_assign.pool", "erin, frank"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	for _, want := range []string{"erin", "frank", "erin"} {
+		issue := &types.Issue{Title: "Triage", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+		if err := store.CreateIssueAutoAssign(ctx, issue, "triage"); err != nil {
+			t.Fatalf("CreateIssueAutoAssign failed: %v", err)
+		}
+		if issue.Assignee != want {
+			t.Errorf("Expected %s, got %q", want, issue.Assignee)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// BlockIssue marks an issue blocked on something outside the tracker and records
+// why. The reason is stored on the issue and as the event comment.
+func (s *SQLiteStorage) BlockIssue(ctx context.Context, id string, reason string, actor string) error {
+	if reason == "" {
+		return types.NewValidationError("blocked_reason", reason, types.ErrInvalidField, "block reason is required")
+	}
+	return s.setBlocked(ctx, id, types.StatusBlocked, reason, actor)
+}
+
+// UnblockIssue returns a blocked issue to open and clears its blocked reason
+func (s *SQLiteStorage) UnblockIssue(ctx context.Context, id string, actor string) error {
+	return s.setBlocked(ctx, id, types.StatusOpen, "", actor)
+}
+
+func (s *SQLiteStorage) setBlocked(ctx context.Context, id string, status types.Status, reason string, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var blockedReason interface{}
+	if reason != "" {
+		blockedReason = reason
+	}
+
+	now := s.now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues SET status = ?, blocked_reason = ?, updated_at = ?
+		WHERE id = ? AND (? = '' OR project_id = ?)
+	`, status, blockedReason, now, id, s.opts.project, s.opts.project)
+	if err != nil {
+		return fmt.Errorf("failed to update blocked state: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	newData, err := json.Marshal(map[string]interface{}{"status": status})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value, comment, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, id, types.EventStatusChanged, actor, string(newData), blockedReason, now)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.notifyWatchers(ctx, id, types.EventStatusChanged, actor)
+	return nil
+}
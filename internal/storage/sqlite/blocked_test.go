@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestBlockIssue(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	blocked := &types.Issue{Title: "Waiting on vendor", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	other := &types.Issue{Title: "Unblocked work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocked, other} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.BlockIssue(ctx, blocked.ID, "", "alice"); err == nil {
+		t.Error("Expected error for empty reason")
+	}
+	if err := store.BlockIssue(ctx, blocked.ID, "waiting on vendor API keys", "alice"); err != nil {
+		t.Fatalf("BlockIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, blocked.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusBlocked || got.BlockedReason != "waiting on vendor API keys" {
+		t.Errorf("Expected blocked with reason, got status %s reason %q", got.Status, got.BlockedReason)
+	}
+
+	yes, no := true, false
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Blocked: &yes})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != blocked.ID || results[0].BlockedReason == "" {
+		t.Errorf("Expected only %s when filtering blocked, got %d issues", blocked.ID, len(results))
+	}
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{Blocked: &no})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != other.ID {
+		t.Errorf("Expected only %s when filtering not blocked, got %d issues", other.ID, len(results))
+	}
+
+	events, err := store.GetEvents(ctx, blocked.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventStatusChanged && e.Comment != nil && *e.Comment == "waiting on vendor API keys" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a status change event carrying the reason")
+	}
+
+	if err := store.UnblockIssue(ctx, blocked.ID, "alice"); err != nil {
+		t.Fatalf("UnblockIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, blocked.ID)
+	if got.Status != types.StatusOpen || got.BlockedReason != "" {
+		t.Errorf("Expected open with no reason after unblock, got status %s reason %q", got.Status, got.BlockedReason)
+	}
+
+	if err := store.BlockIssue(ctx, "vc-9999", "reason", "alice"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
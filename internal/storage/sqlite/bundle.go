@@ -0,0 +1,597 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// bundleVersion is the format version written by ExportBundle. ImportBundle also
+// reads version 1 bundles, which predate everything after Comments in bundle.
+const bundleVersion = 2
+
+// bundle is the portable JSON envelope used by ExportBundle and ImportBundle.
+// Comments are also recorded as events, so their timeline entries travel with the
+// events; bundles written before the comments table existed have no Comments.
+type bundle struct {
+	Version        int                    `json:"version"`
+	ExportedAt     time.Time              `json:"exported_at"`
+	Issues         []*types.Issue         `json:"issues"`
+	Labels         []bundleLabel          `json:"labels"`
+	Dependencies   []*types.Dependency    `json:"dependencies"`
+	Events         []*types.Event         `json:"events"`
+	Comments       []*types.Comment       `json:"comments,omitempty"`
+	Worklog        []bundleWorklog        `json:"worklog,omitempty"`
+	ChecklistItems []*types.ChecklistItem `json:"checklist_items,omitempty"`
+	Flags          []*types.Flag          `json:"flags,omitempty"`
+	Votes          []bundleVote           `json:"votes,omitempty"`
+	Watchers       []bundleWatcher        `json:"watchers,omitempty"`
+	Reminders      []*types.Reminder      `json:"reminders,omitempty"`
+	IDAliases      []bundleAlias          `json:"id_aliases,omitempty"`
+	StatusConfig   []*types.StatusConfig  `json:"status_config,omitempty"`
+	IssueCounters  []bundleCounter        `json:"issue_counters,omitempty"`
+}
+
+type bundleLabel struct {
+	IssueID string `json:"issue_id"`
+	Label   string `json:"label"`
+}
+
+type bundleWorklog struct {
+	IssueID  string    `json:"issue_id"`
+	Minutes  int       `json:"minutes"`
+	Actor    string    `json:"actor"`
+	Note     string    `json:"note,omitempty"`
+	LoggedAt time.Time `json:"logged_at"`
+}
+
+type bundleVote struct {
+	IssueID   string    `json:"issue_id"`
+	Voter     string    `json:"voter"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type bundleWatcher struct {
+	IssueID   string    `json:"issue_id"`
+	User      string    `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type bundleAlias struct {
+	Alias     string    `json:"alias"`
+	IssueID   string    `json:"issue_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type bundleCounter struct {
+	Prefix string `json:"prefix"`
+	LastID int    `json:"last_id"`
+}
+
+// ExportBundle writes every issue with everything attached to it (labels,
+// dependencies, events, comments, worklog, checklist items, flags, votes, watchers,
+// reminders and ID aliases), plus the status configuration and issue ID counters,
+// to w as a single versioned JSON document, for moving a tracker between machines
+// with ImportBundle
+func (s *SQLiteStorage) ExportBundle(ctx context.Context, w io.Writer) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	b := bundle{Version: bundleVersion, ExportedAt: s.now()}
+	scope := []interface{}{s.opts.project, s.opts.project}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE (? = '' OR i.project_id = ?)
+		ORDER BY i.id
+	`, scope...)
+	if err != nil {
+		return fmt.Errorf("failed to export issues: %w", err)
+	}
+	b.Issues, err = s.scanIssues(rows)
+	_ = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	exports := []struct {
+		what  string
+		query string
+		args  []interface{}
+		scan  func(rows *sql.Rows) error
+	}{
+		{"labels", `
+			SELECT l.issue_id, l.label
+			FROM labels l JOIN issues i ON i.id = l.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY l.issue_id, l.label
+		`, scope, func(rows *sql.Rows) error {
+			var l bundleLabel
+			if err := rows.Scan(&l.IssueID, &l.Label); err != nil {
+				return err
+			}
+			b.Labels = append(b.Labels, l)
+			return nil
+		}},
+		{"dependencies", `
+			SELECT d.issue_id, d.depends_on_id, d.type, d.created_at, d.created_by
+			FROM dependencies d JOIN issues i ON i.id = d.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY d.issue_id, d.depends_on_id
+		`, scope, func(rows *sql.Rows) error {
+			var d types.Dependency
+			if err := rows.Scan(&d.IssueID, &d.DependsOnID, &d.Type, &d.CreatedAt, &d.CreatedBy); err != nil {
+				return err
+			}
+			b.Dependencies = append(b.Dependencies, &d)
+			return nil
+		}},
+		{"events", `
+			SELECT e.id, e.issue_id, e.event_type, e.actor, e.old_value, e.new_value, e.comment, e.created_at
+			FROM events e JOIN issues i ON i.id = e.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY e.id
+		`, scope, func(rows *sql.Rows) error {
+			var e types.Event
+			if err := rows.Scan(&e.ID, &e.IssueID, &e.EventType, &e.Actor, &e.OldValue, &e.NewValue, &e.Comment, &e.CreatedAt); err != nil {
+				return err
+			}
+			b.Events = append(b.Events, &e)
+			return nil
+		}},
+		{"comments", `
+			SELECT c.id, c.issue_id, c.author, c.body, c.created_at, c.edited_at
+			FROM comments c JOIN issues i ON i.id = c.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY c.id
+		`, scope, func(rows *sql.Rows) error {
+			var c types.Comment
+			var id int64
+			if err := rows.Scan(&id, &c.IssueID, &c.Author, &c.Body, &c.CreatedAt, &c.EditedAt); err != nil {
+				return err
+			}
+			c.ID = strconv.FormatInt(id, 10)
+			b.Comments = append(b.Comments, &c)
+			return nil
+		}},
+		{"worklog", `
+			SELECT w.issue_id, w.minutes, w.actor, w.note, w.logged_at
+			FROM worklog w JOIN issues i ON i.id = w.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY w.id
+		`, scope, func(rows *sql.Rows) error {
+			var w bundleWorklog
+			if err := rows.Scan(&w.IssueID, &w.Minutes, &w.Actor, &w.Note, &w.LoggedAt); err != nil {
+				return err
+			}
+			b.Worklog = append(b.Worklog, w)
+			return nil
+		}},
+		{"checklist items", `
+			SELECT c.id, c.issue_id, c.text, c.done, c.position
+			FROM checklist_items c JOIN issues i ON i.id = c.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY c.issue_id, c.position
+		`, scope, func(rows *sql.Rows) error {
+			var c types.ChecklistItem
+			if err := rows.Scan(&c.ID, &c.IssueID, &c.Text, &c.Done, &c.Position); err != nil {
+				return err
+			}
+			b.ChecklistItems = append(b.ChecklistItems, &c)
+			return nil
+		}},
+		{"flags", `
+			SELECT f.id, f.issue_id, f.code, f.note, f.flagger, f.created_at, f.resolved_at, f.resolved_by
+			FROM flags f JOIN issues i ON i.id = f.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY f.id
+		`, scope, func(rows *sql.Rows) error {
+			var f types.Flag
+			var resolvedBy sql.NullString
+			if err := rows.Scan(&f.ID, &f.IssueID, &f.Code, &f.Note, &f.Flagger, &f.CreatedAt, &f.ResolvedAt, &resolvedBy); err != nil {
+				return err
+			}
+			f.ResolvedBy = resolvedBy.String
+			b.Flags = append(b.Flags, &f)
+			return nil
+		}},
+		{"votes", `
+			SELECT v.issue_id, v.voter, v.created_at
+			FROM votes v JOIN issues i ON i.id = v.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY v.issue_id, v.voter
+		`, scope, func(rows *sql.Rows) error {
+			var v bundleVote
+			if err := rows.Scan(&v.IssueID, &v.Voter, &v.CreatedAt); err != nil {
+				return err
+			}
+			b.Votes = append(b.Votes, v)
+			return nil
+		}},
+		{"watchers", `
+			SELECT w.issue_id, w.user, w.created_at
+			FROM watchers w JOIN issues i ON i.id = w.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY w.issue_id, w.user
+		`, scope, func(rows *sql.Rows) error {
+			var w bundleWatcher
+			if err := rows.Scan(&w.IssueID, &w.User, &w.CreatedAt); err != nil {
+				return err
+			}
+			b.Watchers = append(b.Watchers, w)
+			return nil
+		}},
+		{"reminders", `
+			SELECT r.id, r.issue_id, r.remind_at, r.note, r.recipient, r.sent_at
+			FROM reminders r JOIN issues i ON i.id = r.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY r.id
+		`, scope, func(rows *sql.Rows) error {
+			var r types.Reminder
+			if err := rows.Scan(&r.ID, &r.IssueID, &r.RemindAt, &r.Note, &r.Recipient, &r.SentAt); err != nil {
+				return err
+			}
+			b.Reminders = append(b.Reminders, &r)
+			return nil
+		}},
+		{"ID aliases", `
+			SELECT a.alias, a.issue_id, a.created_at
+			FROM id_aliases a JOIN issues i ON i.id = a.issue_id
+			WHERE (? = '' OR i.project_id = ?)
+			ORDER BY a.alias
+		`, scope, func(rows *sql.Rows) error {
+			var a bundleAlias
+			if err := rows.Scan(&a.Alias, &a.IssueID, &a.CreatedAt); err != nil {
+				return err
+			}
+			b.IDAliases = append(b.IDAliases, a)
+			return nil
+		}},
+		// Status configuration is shared by all projects
+		{"status config", `
+			SELECT status, display_order, category
+			FROM status_config
+			ORDER BY display_order, status
+		`, nil, func(rows *sql.Rows) error {
+			var c types.StatusConfig
+			if err := rows.Scan(&c.Status, &c.DisplayOrder, &c.Category); err != nil {
+				return err
+			}
+			b.StatusConfig = append(b.StatusConfig, &c)
+			return nil
+		}},
+		// Project-scoped storage numbers IDs with the project as the prefix
+		{"issue counters", `
+			SELECT prefix, last_id
+			FROM issue_counters
+			WHERE (? = '' OR prefix = ?)
+			ORDER BY prefix
+		`, scope, func(rows *sql.Rows) error {
+			var c bundleCounter
+			if err := rows.Scan(&c.Prefix, &c.LastID); err != nil {
+				return err
+			}
+			b.IssueCounters = append(b.IssueCounters, c)
+			return nil
+		}},
+	}
+	for _, export := range exports {
+		if err := exportRows(ctx, tx, export.query, export.args, export.scan); err != nil {
+			return fmt.Errorf("failed to export %s: %w", export.what, err)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&b); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// exportRows runs query in tx and calls scan for each row
+func exportRows(ctx context.Context, tx *sql.Tx, query string, args []interface{}, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// execer is implemented by *sql.DB, *sql.Conn and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// writeImportedIssue inserts an imported issue with its own ID and timestamps, with
+// encrypted columns in their stored form. With overwrite, an existing issue with the
+// same ID is replaced in place, keeping its labels, dependencies and history.
+func (s *SQLiteStorage) writeImportedIssue(ctx context.Context, db execer, issue *types.Issue, overwrite bool) error {
+	stored, err := s.cipher.encryptIssue(issue)
+	if err != nil {
+		return err
+	}
+	upsert := ""
+	if overwrite {
+		upsert = `
+			ON CONFLICT (id) DO UPDATE SET
+				title = excluded.title, description = excluded.description,
+				design = excluded.design, acceptance_criteria = excluded.acceptance_criteria,
+				notes = excluded.notes, status = excluded.status, priority = excluded.priority,
+				issue_type = excluded.issue_type, assignee = excluded.assignee,
+				estimated_minutes = excluded.estimated_minutes,
+				estimate_min_minutes = excluded.estimate_min_minutes,
+				estimate_max_minutes = excluded.estimate_max_minutes,
+				project_id = excluded.project_id, pinned = excluded.pinned,
+				blocked_reason = excluded.blocked_reason, reviewer = excluded.reviewer,
+				due_at = excluded.due_at, archived_at = excluded.archived_at,
+				created_at = excluded.created_at, updated_at = excluded.updated_at,
+				closed_at = excluded.closed_at,
+				approved_at = excluded.approved_at, approved_by = excluded.approved_by`
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO issues (
+			id, title, description, design, acceptance_criteria, notes,
+			status, priority, issue_type, assignee, estimated_minutes,
+			estimate_min_minutes, estimate_max_minutes, project_id, pinned,
+			blocked_reason, reviewer, due_at, archived_at, created_at, updated_at, closed_at,
+			approved_at, approved_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`+upsert,
+		stored.ID, stored.Title, stored.Description, stored.Design,
+		stored.AcceptanceCriteria, stored.Notes, stored.Status,
+		stored.Priority, stored.IssueType, stored.Assignee,
+		stored.EstimatedMinutes, stored.EstimateMinMinutes, stored.EstimateMaxMinutes,
+		stored.ProjectID, stored.Pinned, sql.NullString{String: stored.BlockedReason, Valid: stored.BlockedReason != ""},
+		stored.Reviewer, stored.DueAt, stored.ArchivedAt, stored.CreatedAt, stored.UpdatedAt, stored.ClosedAt,
+		stored.ApprovedAt, sql.NullString{String: stored.ApprovedBy, Valid: stored.ApprovedBy != ""},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to import issue %s: %w", issue.ID, err)
+	}
+	return nil
+}
+
+// ImportBundle restores a bundle written by ExportBundle in a single transaction,
+// preserving issue IDs and all timestamps. It fails without writing anything if the
+// bundle version is unsupported, an issue is invalid, or an ID already exists.
+// Each imported issue gets a comment event by actor noting the import.
+func (s *SQLiteStorage) ImportBundle(ctx context.Context, r io.Reader, actor string) error {
+	return s.ImportBundleWithOptions(ctx, r, types.ImportOptions{}, actor)
+}
+
+// ImportBundleWithOptions is ImportBundle adjusted by opts. With SkipImportEvents
+// the per-issue import events are not written, trading the audit trail on each
+// issue for throughput; the bundle's own event history is still imported. With
+// DeferForeignKeys, foreign keys are checked once before commit rather than per row.
+func (s *SQLiteStorage) ImportBundleWithOptions(ctx context.Context, r io.Reader, opts types.ImportOptions, actor string) error {
+	var b bundle
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	if b.Version < 1 || b.Version > bundleVersion {
+		return fmt.Errorf("unsupported bundle version %d (expected 1 to %d)", b.Version, bundleVersion)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if opts.DeferForeignKeys {
+		// Lasts until the transaction ends
+		if _, err := tx.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return fmt.Errorf("failed to defer foreign keys: %w", err)
+		}
+	}
+
+	// Issues may use statuses the bundle configures, so this comes first
+	for _, c := range b.StatusConfig {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO status_config (status, display_order, category) VALUES (?, ?, ?)
+			ON CONFLICT (status) DO UPDATE SET
+				display_order = excluded.display_order,
+				category = excluded.category
+		`, c.Status, c.DisplayOrder, c.Category)
+		if err != nil {
+			return fmt.Errorf("failed to import status config for %s: %w", c.Status, err)
+		}
+	}
+
+	for _, issue := range b.Issues {
+		if err := issue.ValidateWithMaxPriority(s.maxPriority); err != nil {
+			return fmt.Errorf("invalid issue %s in bundle: %w", issue.ID, err)
+		}
+		if s.opts.project != "" {
+			issue.ProjectID = s.opts.project
+		}
+		if err := s.writeImportedIssue(ctx, tx, issue, false); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range b.Labels {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO labels (issue_id, label) VALUES (?, ?)`, l.IssueID, l.Label); err != nil {
+			return fmt.Errorf("failed to import label %s on %s: %w", l.Label, l.IssueID, err)
+		}
+	}
+
+	for _, d := range b.Dependencies {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+			VALUES (?, ?, ?, ?, ?)
+		`, d.IssueID, d.DependsOnID, d.Type, d.CreatedAt, d.CreatedBy)
+		if err != nil {
+			return fmt.Errorf("failed to import dependency %s -> %s: %w", d.IssueID, d.DependsOnID, err)
+		}
+	}
+
+	// Event IDs are local to a database, so imported events get new ones
+	for _, e := range b.Events {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, e.IssueID, e.EventType, e.Actor, e.OldValue, e.NewValue, e.Comment, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import event for %s: %w", e.IssueID, err)
+		}
+	}
+
+	// Comment IDs are local too
+	for _, c := range b.Comments {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO comments (issue_id, author, body, created_at, edited_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, c.IssueID, c.Author, c.Body, c.CreatedAt, c.EditedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import comment for %s: %w", c.IssueID, err)
+		}
+	}
+
+	// Worklog, checklist, flag and reminder IDs are local too
+	for _, w := range b.Worklog {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO worklog (issue_id, minutes, actor, note, logged_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, w.IssueID, w.Minutes, w.Actor, w.Note, w.LoggedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import worklog for %s: %w", w.IssueID, err)
+		}
+	}
+
+	for _, c := range b.ChecklistItems {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO checklist_items (issue_id, text, done, position)
+			VALUES (?, ?, ?, ?)
+		`, c.IssueID, c.Text, c.Done, c.Position)
+		if err != nil {
+			return fmt.Errorf("failed to import checklist item for %s: %w", c.IssueID, err)
+		}
+	}
+
+	for _, f := range b.Flags {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO flags (issue_id, code, note, flagger, created_at, resolved_at, resolved_by)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, f.IssueID, f.Code, f.Note, f.Flagger, f.CreatedAt, f.ResolvedAt,
+			sql.NullString{String: f.ResolvedBy, Valid: f.ResolvedBy != ""})
+		if err != nil {
+			return fmt.Errorf("failed to import flag for %s: %w", f.IssueID, err)
+		}
+	}
+
+	for _, v := range b.Votes {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO votes (issue_id, voter, created_at) VALUES (?, ?, ?)
+		`, v.IssueID, v.Voter, v.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import vote for %s: %w", v.IssueID, err)
+		}
+	}
+
+	for _, w := range b.Watchers {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO watchers (issue_id, user, created_at) VALUES (?, ?, ?)
+		`, w.IssueID, w.User, w.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import watcher for %s: %w", w.IssueID, err)
+		}
+	}
+
+	for _, r := range b.Reminders {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO reminders (issue_id, remind_at, note, recipient, sent_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, r.IssueID, r.RemindAt, r.Note, r.Recipient, r.SentAt)
+		if err != nil {
+			return fmt.Errorf("failed to import reminder for %s: %w", r.IssueID, err)
+		}
+	}
+
+	for _, a := range b.IDAliases {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO id_aliases (alias, issue_id, created_at) VALUES (?, ?, ?)
+		`, a.Alias, a.IssueID, a.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import alias %s for %s: %w", a.Alias, a.IssueID, err)
+		}
+	}
+
+	// Counters only move forward, so IDs freed by deleted issues aren't reused
+	for _, c := range b.IssueCounters {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO issue_counters (prefix, last_id) VALUES (?, ?)
+			ON CONFLICT (prefix) DO UPDATE SET last_id = MAX(last_id, excluded.last_id)
+		`, c.Prefix, c.LastID)
+		if err != nil {
+			return fmt.Errorf("failed to import issue counter for %s: %w", c.Prefix, err)
+		}
+	}
+
+	if !opts.SkipImportEvents {
+		now := s.now()
+		for _, issue := range b.Issues {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+				VALUES (?, ?, ?, ?, ?)
+			`, issue.ID, types.EventCommented, actor, "Imported from bundle", now)
+			if err != nil {
+				return fmt.Errorf("failed to record event: %w", err)
+			}
+		}
+	}
+
+	if opts.DeferForeignKeys {
+		if err := checkForeignKeys(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkForeignKeys runs PRAGMA foreign_key_check in tx and returns an error
+// describing the violations, if there are any
+func checkForeignKeys(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var violations []string
+	for rows.Next() {
+		var table, parent string
+		var rowID sql.NullInt64
+		var fkID int
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return fmt.Errorf("failed to scan foreign key violation: %w", err)
+		}
+		violations = append(violations, fmt.Sprintf("%s row %d references missing %s", table, rowID.Int64, parent))
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating foreign key violations: %w", err)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("foreign key check failed with %d violation(s): %s", len(violations), strings.Join(violations, "; "))
+	}
+	return nil
+}
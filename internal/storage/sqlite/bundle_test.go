@@ -0,0 +1,374 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	src := setupTestDB(t)
+	ctx := context.Background()
+
+	epic := &types.Issue{Title: "Epic", Description: "big", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic, EstimatedMinutes: intPtr(120)}
+	task := &types.Issue{Title: "Task", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask, Assignee: "alice"}
+	for _, issue := range []*types.Issue{epic, task} {
+		if err := src.CreateIssue(ctx, issue, "creator"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := src.AddLabel(ctx, task.ID, "backend", "creator"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := src.AddDependency(ctx, &types.Dependency{IssueID: task.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "creator"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := src.AddComment(ctx, task.ID, "bob", "looks good"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := src.CloseIssue(ctx, epic.ID, "done", "creator"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportBundle(ctx, &buf); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+
+	dst := setupTestDB(t)
+	if err := dst.ImportBundle(ctx, bytes.NewReader(buf.Bytes()), "importer"); err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+
+	for _, id := range []string{epic.ID, task.ID} {
+		want, _ := src.GetIssue(ctx, id)
+		got, err := dst.GetIssue(ctx, id)
+		if err != nil || got == nil {
+			t.Fatalf("Expected %s to be imported, got %v", id, err)
+		}
+		if got.Title != want.Title || got.Status != want.Status || got.Assignee != want.Assignee ||
+			!got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) ||
+			(want.ClosedAt != nil) != (got.ClosedAt != nil) {
+			t.Errorf("Issue %s differs after import:\n got  %+v\n want %+v", id, got, want)
+		}
+	}
+
+	labels, _ := dst.GetLabels(ctx, task.ID)
+	if len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("Expected label to be imported, got %v", labels)
+	}
+	deps, _ := dst.GetDependencyRecords(ctx, task.ID)
+	if len(deps) != 1 || deps[0].DependsOnID != epic.ID || deps[0].Type != types.DepParentChild {
+		t.Errorf("Expected dependency to be imported, got %+v", deps)
+	}
+
+	srcEvents, _ := src.GetEvents(ctx, task.ID, 0)
+	dstEvents, _ := dst.GetEvents(ctx, task.ID, 0)
+	if len(dstEvents) != len(srcEvents)+1 {
+		t.Fatalf("Expected %d events plus the import note, got %d", len(srcEvents), len(dstEvents))
+	}
+	var sawComment, sawImport bool
+	for _, e := range dstEvents {
+		if e.EventType == types.EventCommented && e.Comment != nil {
+			sawComment = sawComment || (*e.Comment == "looks good" && e.Actor == "bob")
+			sawImport = sawImport || (*e.Comment == "Imported from bundle" && e.Actor == "importer")
+		}
+	}
+	if !sawComment || !sawImport {
+		t.Errorf("Expected the comment and an import note, got comment=%v import=%v", sawComment, sawImport)
+	}
+
+	// New issues continue numbering after the imported IDs
+	next := &types.Issue{Title: "After import", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := dst.CreateIssue(ctx, next, "test"); err != nil {
+		t.Fatalf("CreateIssue after import failed: %v", err)
+	}
+	if next.ID == epic.ID || next.ID == task.ID {
+		t.Errorf("Expected a fresh ID after import, got %s", next.ID)
+	}
+
+	// Importing again collides on IDs and writes nothing
+	if err := dst.ImportBundle(ctx, bytes.NewReader(buf.Bytes()), "importer"); err == nil {
+		t.Error("Expected duplicate import to fail")
+	}
+	all, _ := dst.SearchIssues(ctx, "", types.IssueFilter{})
+	if len(all) != 3 {
+		t.Errorf("Expected failed import to roll back, got %d issues", len(all))
+	}
+}
+
+// TestBundleRoundTripAttachedData verifies the tables hanging off issues, the
+// status configuration and the ID counters travel with a bundle
+func TestBundleRoundTripAttachedData(t *testing.T) {
+	src := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Attached", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: intPtr(60)}
+	deleted := &types.Issue{Title: "Deleted", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, deleted} {
+		if err := src.CreateIssue(ctx, i, "creator"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := src.DeleteIssue(ctx, deleted.ID, "creator"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	if err := src.LogWork(ctx, issue.ID, 45, "alice", "spike"); err != nil {
+		t.Fatalf("LogWork failed: %v", err)
+	}
+	itemID, err := src.AddChecklistItem(ctx, issue.ID, "write tests")
+	if err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	if err := src.SetChecklistItemDone(ctx, itemID, true); err != nil {
+		t.Fatalf("SetChecklistItemDone failed: %v", err)
+	}
+	if _, err := src.AddChecklistItem(ctx, issue.ID, "ship it"); err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	flagID, err := src.FlagIssue(ctx, issue.ID, types.FlagNeedsTriage, "who owns this?", "bob")
+	if err != nil {
+		t.Fatalf("FlagIssue failed: %v", err)
+	}
+	if err := src.ResolveFlag(ctx, flagID, "alice"); err != nil {
+		t.Fatalf("ResolveFlag failed: %v", err)
+	}
+	if err := src.Vote(ctx, issue.ID, "carol"); err != nil {
+		t.Fatalf("Vote failed: %v", err)
+	}
+	if err := src.AddWatcher(ctx, issue.ID, "dave"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+	remindAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	if _, err := src.AddReminder(ctx, issue.ID, remindAt, "follow up", "alice"); err != nil {
+		t.Fatalf("AddReminder failed: %v", err)
+	}
+	if err := src.AddIDAlias(ctx, issue.ID, "JIRA-7"); err != nil {
+		t.Fatalf("AddIDAlias failed: %v", err)
+	}
+	if err := src.SetStatusConfig(ctx, &types.StatusConfig{Status: types.StatusBlocked, DisplayOrder: 9, Category: types.StatusCategoryTodo}); err != nil {
+		t.Fatalf("SetStatusConfig failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportBundle(ctx, &buf); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	dst := setupTestDB(t)
+	if err := dst.ImportBundle(ctx, bytes.NewReader(buf.Bytes()), "importer"); err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+
+	if minutes, _ := dst.GetLoggedMinutes(ctx, issue.ID); minutes != 45 {
+		t.Errorf("Expected 45 logged minutes, got %d", minutes)
+	}
+	checklist, _ := dst.GetChecklist(ctx, issue.ID)
+	if len(checklist) != 2 || checklist[0].Text != "write tests" || !checklist[0].Done || checklist[1].Done {
+		t.Errorf("Expected the checklist to be imported in order, got %+v", checklist)
+	}
+	flags, _ := dst.GetFlags(ctx, issue.ID)
+	if len(flags) != 1 || flags[0].Code != types.FlagNeedsTriage || flags[0].ResolvedAt == nil || flags[0].ResolvedBy != "alice" {
+		t.Errorf("Expected the resolved flag to be imported, got %+v", flags)
+	}
+	if votes, _ := dst.GetVoteCount(ctx, issue.ID); votes != 1 {
+		t.Errorf("Expected 1 vote, got %d", votes)
+	}
+	if watchers, _ := dst.GetWatchers(ctx, issue.ID); len(watchers) != 1 || watchers[0] != "dave" {
+		t.Errorf("Expected dave to watch, got %v", watchers)
+	}
+	due, _ := dst.GetDueReminders(ctx, time.Now())
+	if len(due) != 1 || due[0].Note != "follow up" || !due[0].RemindAt.Equal(remindAt) {
+		t.Errorf("Expected the reminder to be imported, got %+v", due)
+	}
+	if id, err := dst.ResolveID(ctx, "JIRA-7"); err != nil || id != issue.ID {
+		t.Errorf("Expected alias JIRA-7 to resolve to %s, got %q (%v)", issue.ID, id, err)
+	}
+	configs, _ := dst.GetStatusConfig(ctx)
+	var blocked *types.StatusConfig
+	for _, c := range configs {
+		if c.Status == types.StatusBlocked {
+			blocked = c
+		}
+	}
+	if blocked == nil || blocked.DisplayOrder != 9 || blocked.Category != types.StatusCategoryTodo {
+		t.Errorf("Expected the blocked status config to be imported, got %+v", blocked)
+	}
+
+	// The deleted issue's ID isn't handed out again
+	next := &types.Issue{Title: "After import", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := dst.CreateIssue(ctx, next, "test"); err != nil {
+		t.Fatalf("CreateIssue after import failed: %v", err)
+	}
+	if next.ID == deleted.ID {
+		t.Errorf("Expected the imported counter to skip %s", deleted.ID)
+	}
+}
+
+// TestImportBundleVersion1 verifies bundles written before the attached tables
+// were exported still import
+func TestImportBundleVersion1(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Format(time.RFC3339)
+	bundle := `{"version": 1, "exported_at": "` + now + `", "issues": [{"id": "test-5", "title": "Old",
+		"status": "open", "priority": 2, "issue_type": "task", "created_at": "` + now + `", "updated_at": "` + now + `"}],
+		"labels": [], "dependencies": [], "events": []}`
+	if err := store.ImportBundle(ctx, strings.NewReader(bundle), "test"); err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, "test-5"); got == nil || got.Title != "Old" {
+		t.Errorf("Expected test-5 to be imported, got %+v", got)
+	}
+}
+
+func TestImportBundleRejectsUnknownVersion(t *testing.T) {
+	store := setupTestDB(t)
+	bundle := `{"version": 99, "exported_at": "` + time.Now().Format(time.RFC3339) + `", "issues": []}`
+	err := store.ImportBundle(context.Background(), strings.NewReader(bundle), "test")
+	if err == nil || !strings.Contains(err.Error(), "unsupported bundle version") {
+		t.Errorf("Expected unsupported version error, got %v", err)
+	}
+}
+
+func TestImportBundleSkipImportEvents(t *testing.T) {
+	src := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Historical", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := src.CreateIssue(ctx, issue, "creator"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := src.ExportBundle(ctx, &buf); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+
+	dst := setupTestDB(t)
+	if err := dst.ImportBundleWithOptions(ctx, &buf, types.ImportOptions{SkipImportEvents: true}, "importer"); err != nil {
+		t.Fatalf("ImportBundleWithOptions failed: %v", err)
+	}
+
+	events, err := dst.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	// Only the bundle's own creation event, no import comment
+	if len(events) != 1 || events[0].EventType != types.EventCreated {
+		t.Errorf("Expected only the original creation event, got %d events", len(events))
+	}
+}
+
+func TestImportBundleDeferForeignKeys(t *testing.T) {
+	src := setupTestDB(t)
+	ctx := context.Background()
+
+	// A chain of issues that reference each other through dependencies and labels
+	var ids []string
+	for i := 0; i < 4; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Step %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := src.CreateIssue(ctx, issue, "creator"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := src.AddLabel(ctx, issue.ID, "chain", "creator"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	for i := 1; i < len(ids); i++ {
+		dep := &types.Dependency{IssueID: ids[i], DependsOnID: ids[i-1], Type: types.DepBlocks}
+		if err := src.AddDependency(ctx, dep, "creator"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := src.ExportBundle(ctx, &buf); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	data := buf.String()
+
+	dst := setupTestDB(t)
+	opts := types.ImportOptions{DeferForeignKeys: true}
+	if err := dst.ImportBundleWithOptions(ctx, strings.NewReader(data), opts, "importer"); err != nil {
+		t.Fatalf("ImportBundleWithOptions failed: %v", err)
+	}
+	rows, err := dst.db.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		t.Fatalf("foreign_key_check failed: %v", err)
+	}
+	if rows.Next() {
+		t.Error("expected no foreign key violations after import")
+	}
+	_ = rows.Close()
+	deps, err := dst.GetDependencyRecords(ctx, ids[3])
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != ids[2] {
+		t.Errorf("expected %s to depend on %s after import, got %v (%v)", ids[3], ids[2], deps, err)
+	}
+
+	// A dependency on an issue missing from the bundle fails the whole import
+	dangling := strings.Replace(data, `"depends_on_id": "`+ids[0]+`"`, `"depends_on_id": "vc-999"`, 1)
+	if dangling == data {
+		t.Fatal("failed to build a bundle with a dangling dependency")
+	}
+	empty := setupTestDB(t)
+	err = empty.ImportBundleWithOptions(ctx, strings.NewReader(dangling), opts, "importer")
+	if err == nil || !strings.Contains(err.Error(), "foreign key check failed") {
+		t.Fatalf("expected foreign key check failure, got %v", err)
+	}
+	if issue, _ := empty.GetIssue(ctx, ids[0]); issue != nil {
+		t.Error("expected failed import to write nothing")
+	}
+}
+
+func BenchmarkImportBundle(b *testing.B) {
+	ctx := context.Background()
+	src, err := New(filepath.Join(b.TempDir(), "src.db"))
+	if err != nil {
+		b.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = src.Close() }()
+	for n := 0; n < 500; n++ {
+		issue := &types.Issue{Title: "Bulk", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := src.CreateIssue(ctx, issue, "bench"); err != nil {
+			b.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := src.ExportBundle(ctx, &buf); err != nil {
+		b.Fatalf("ExportBundle failed: %v", err)
+	}
+	bundle := buf.Bytes()
+
+	for _, bc := range []struct {
+		name string
+		opts types.ImportOptions
+	}{
+		{"events", types.ImportOptions{}},
+		{"no-events", types.ImportOptions{SkipImportEvents: true}},
+		{"deferred-fks", types.ImportOptions{DeferForeignKeys: true}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			dir := b.TempDir()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				dst, err := New(filepath.Join(dir, fmt.Sprintf("dst-%d.db", i)))
+				if err != nil {
+					b.Fatalf("New failed: %v", err)
+				}
+				b.StartTimer()
+				if err := dst.ImportBundleWithOptions(ctx, bytes.NewReader(bundle), bc.opts, "bench"); err != nil {
+					b.Fatalf("Import failed: %v", err)
+				}
+				b.StopTimer()
+				_ = dst.Close()
+				b.StartTimer()
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// AddChecklistItem appends an item to the end of an issue's checklist and returns its
+// ID. Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) AddChecklistItem(ctx context.Context, issueID, text string) (int64, error) {
+	if strings.TrimSpace(text) == "" {
+		return 0, types.NewValidationError("text", text, types.ErrInvalidField, "checklist item text is required")
+	}
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO checklist_items (issue_id, text, position)
+		VALUES (?, ?, (SELECT COALESCE(MAX(position), -1) + 1 FROM checklist_items WHERE issue_id = ?))
+	`, issueID, text, issueID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add checklist item: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get checklist item ID: %w", err)
+	}
+	return id, nil
+}
+
+// SetChecklistItemDone checks or unchecks a checklist item
+func (s *SQLiteStorage) SetChecklistItemDone(ctx context.Context, itemID int64, done bool) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE checklist_items SET done = ? WHERE id = ?`, done, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to update checklist item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("checklist item %d not found", itemID)
+	}
+	return nil
+}
+
+// GetChecklist returns an issue's checklist items in order
+func (s *SQLiteStorage) GetChecklist(ctx context.Context, issueID string) ([]*types.ChecklistItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, text, done, position
+		FROM checklist_items
+		WHERE issue_id = ?
+		ORDER BY position ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var items []*types.ChecklistItem
+	for rows.Next() {
+		var item types.ChecklistItem
+		if err := rows.Scan(&item.ID, &item.IssueID, &item.Text, &item.Done, &item.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checklist: %w", err)
+	}
+
+	return items, nil
+}
+
+// checkChecklistComplete returns ErrChecklistIncomplete, listing the unfinished
+// items, if the issue's checklist has any items that are not done
+func checkChecklistComplete(ctx context.Context, tx *sql.Tx, issueID string) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT text FROM checklist_items
+		WHERE issue_id = ? AND done = 0
+		ORDER BY position ASC
+	`, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to check checklist: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var pending []string
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+		pending = append(pending, text)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating checklist: %w", err)
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("cannot close issue %s: %w: %s", issueID, ErrChecklistIncomplete, strings.Join(pending, "; "))
+	}
+	return nil
+}
+
+// GetIssuesByCriteriaProgress returns in-progress issues whose acceptance criteria
+// are less than maxPercent (0-100) complete, by priority then age. Progress comes
+// from the issue's checklist when it has one, otherwise from markdown checkboxes
+// ("- [x]" / "- [ ]") in the acceptance_criteria text. Issues with neither have no
+// measurable progress and are left out.
+func (s *SQLiteStorage) GetIssuesByCriteriaProgress(ctx context.Context, maxPercent float64) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`,
+		       (SELECT COUNT(*) FROM checklist_items c WHERE c.issue_id = i.id),
+		       (SELECT COUNT(*) FROM checklist_items c WHERE c.issue_id = i.id AND c.done = 1)
+		FROM issues i
+		WHERE i.status = ? AND (? = '' OR i.project_id = ?)
+		ORDER BY i.priority ASC, i.created_at ASC
+	`, types.StatusInProgress, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-progress issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var issues []*types.Issue
+	for rows.Next() {
+		var total, done int
+		issue, err := s.scanIssue(rows, &total, &done)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		if total == 0 {
+			done, total = criteriaCheckboxes(issue.AcceptanceCriteria)
+		}
+		if total > 0 && float64(done)*100/float64(total) < maxPercent {
+			issues = append(issues, issue)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// criteriaCheckboxes counts the checked and total markdown checkboxes in text
+func criteriaCheckboxes(text string) (done, total int) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- [") && !strings.HasPrefix(line, "* [") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line[2:], "[x]"), strings.HasPrefix(line[2:], "[X]"):
+			done++
+			total++
+		case strings.HasPrefix(line[2:], "[ ]"):
+			total++
+		}
+	}
+	return done, total
+}
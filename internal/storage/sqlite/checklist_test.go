@@ -0,0 +1,167 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestChecklist(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Release", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	for _, text := range []string{"Tag", "Build", "Announce"} {
+		if _, err := store.AddChecklistItem(ctx, issue.ID, text); err != nil {
+			t.Fatalf("AddChecklistItem failed: %v", err)
+		}
+	}
+	if _, err := store.AddChecklistItem(ctx, issue.ID, "  "); err == nil {
+		t.Error("Expected error for empty checklist item")
+	}
+
+	items, err := store.GetChecklist(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetChecklist failed: %v", err)
+	}
+	if len(items) != 3 || items[0].Text != "Tag" || items[2].Text != "Announce" {
+		t.Fatalf("Unexpected checklist: %+v", items)
+	}
+
+	if err := store.SetChecklistItemDone(ctx, items[1].ID, true); err != nil {
+		t.Fatalf("SetChecklistItemDone failed: %v", err)
+	}
+	items, _ = store.GetChecklist(ctx, issue.ID)
+	if items[0].Done || !items[1].Done {
+		t.Errorf("Expected only Build to be done, got %+v %+v", items[0], items[1])
+	}
+
+	if err := store.SetChecklistItemDone(ctx, 9999, true); err == nil {
+		t.Error("Expected error for non-existent checklist item")
+	}
+}
+
+func TestCloseIssueChecklistGated(t *testing.T) {
+	store := setupTestDB(t, WithChecklistGatedClose())
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Release", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	var ids []int64
+	for _, text := range []string{"Tag", "Build"} {
+		id, err := store.AddChecklistItem(ctx, issue.ID, text)
+		if err != nil {
+			t.Fatalf("AddChecklistItem failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := store.SetChecklistItemDone(ctx, ids[0], true); err != nil {
+		t.Fatalf("SetChecklistItemDone failed: %v", err)
+	}
+
+	err := store.CloseIssue(ctx, issue.ID, "done", "test")
+	if !errors.Is(err, ErrChecklistIncomplete) {
+		t.Fatalf("Expected ErrChecklistIncomplete, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Build") || strings.Contains(err.Error(), "Tag") {
+		t.Errorf("Expected error to list only the unfinished item, got %v", err)
+	}
+	// Closing through UpdateIssue is gated too
+	err = store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusClosed)}, "test")
+	if !errors.Is(err, ErrChecklistIncomplete) {
+		t.Fatalf("Expected ErrChecklistIncomplete from UpdateIssue, got %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen {
+		t.Errorf("Expected issue to stay open, got %s", got.Status)
+	}
+	// Other updates are not gated
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	if err := store.SetChecklistItemDone(ctx, ids[1], true); err != nil {
+		t.Fatalf("SetChecklistItemDone failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatalf("Expected close to succeed with a complete checklist, got %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusClosed {
+		t.Errorf("Expected issue to be closed, got %s", got.Status)
+	}
+}
+
+func TestCloseIssueChecklistNotGatedByDefault(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Release", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if _, err := store.AddChecklistItem(ctx, issue.ID, "Tag"); err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Errorf("Expected close to ignore the checklist by default, got %v", err)
+	}
+}
+
+func TestGetIssuesByCriteriaProgress(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	create := func(title, criteria string, status types.Status) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, AcceptanceCriteria: criteria, Status: status, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+
+	half := create("Half done", "- [x] Parser\n- [ ] Tests\n  - [X] Docs\n* [ ] Release notes", types.StatusInProgress)
+	create("Nearly done", "- [x] One\n- [x] Two\n- [x] Three\n- [ ] Four", types.StatusInProgress)
+	create("No checkboxes", "It works", types.StatusInProgress)
+	create("Not started", "- [ ] Everything", types.StatusOpen)
+
+	// A structured checklist takes precedence over the text
+	tracked := create("Tracked", "- [ ] Ignored", types.StatusInProgress)
+	for _, text := range []string{"First", "Second", "Third"} {
+		id, err := store.AddChecklistItem(ctx, tracked.ID, text)
+		if err != nil {
+			t.Fatalf("AddChecklistItem failed: %v", err)
+		}
+		if text != "Third" {
+			if err := store.SetChecklistItemDone(ctx, id, true); err != nil {
+				t.Fatalf("SetChecklistItemDone failed: %v", err)
+			}
+		}
+	}
+
+	got, err := store.GetIssuesByCriteriaProgress(ctx, 60)
+	if err != nil {
+		t.Fatalf("GetIssuesByCriteriaProgress failed: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != half.ID {
+		t.Fatalf("Expected only the half-done issue below 60%%, got %d issues", len(got))
+	}
+
+	got, err = store.GetIssuesByCriteriaProgress(ctx, 70)
+	if err != nil {
+		t.Fatalf("GetIssuesByCriteriaProgress failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Expected the half-done and tracked (67%%) issues below 70%%, got %d", len(got))
+	}
+}
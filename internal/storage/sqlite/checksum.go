@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// checksumAlphabet holds the 31 characters a checksum can be: digits and lowercase
+// letters without the easily confused 0, 1, i, l and o. Its length is prime, so the
+// position-weighted sum catches every single-character typo in the digits and
+// every swap of two adjacent digits.
+const checksumAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+
+// idChecksum computes the checksum character for an ID without its suffix
+func idChecksum(base string) byte {
+	sum := 0
+	for i := 0; i < len(base); i++ {
+		sum += (i + 1) * int(base[i])
+	}
+	return checksumAlphabet[sum%len(checksumAlphabet)]
+}
+
+// withChecksum appends the checksum suffix to an ID (e.g. "bd-123" → "bd-123-k")
+func withChecksum(base string) string {
+	return base + "-" + string(idChecksum(base))
+}
+
+// splitChecksum splits an ID of the form prefix-number-check into its base and
+// checksum character. ok is false when the ID doesn't have that shape.
+func splitChecksum(id string) (base string, check byte, ok bool) {
+	i := strings.LastIndexByte(id, '-')
+	if i < 0 || len(id)-i != 2 {
+		return "", 0, false
+	}
+	base = id[:i]
+	j := strings.LastIndexByte(base, '-')
+	if j < 0 || j == len(base)-1 || strings.Trim(base[j+1:], "0123456789") != "" {
+		return "", 0, false
+	}
+	return base, id[i+1], true
+}
+
+// ValidateID reports whether id carries a correct checksum suffix, as generated
+// with WithIDChecksum (e.g. "bd-123-k"). IDs without a suffix, or with a suffix
+// that doesn't match, are invalid.
+func ValidateID(id string) bool {
+	base, check, ok := splitChecksum(id)
+	return ok && idChecksum(base) == check
+}
+
+// ResolveID returns the stored ID an issue reference points to. The reference may
+// be the full ID, an alias added with AddIDAlias or, for IDs generated with
+// WithIDChecksum, the ID without its checksum suffix. A reference that matches no
+// issue and whose checksum doesn't match is reported as a typo.
+func (s *SQLiteStorage) ResolveID(ctx context.Context, ref string) (string, error) {
+	found, err := s.issueExists(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		return ref, nil
+	}
+	if id, err := s.resolveAlias(ctx, ref); err != nil || id != "" {
+		return id, err
+	}
+
+	if base, check, ok := splitChecksum(ref); ok && idChecksum(base) != check {
+		return "", fmt.Errorf("invalid issue reference %s: checksum mismatch", ref)
+	}
+	if id := withChecksum(ref); ValidateID(id) {
+		found, err := s.issueExists(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("issue %s: %w", ref, ErrIssueNotFound)
+}
+
+// issueExists reports whether an issue with exactly this ID is visible to the storage
+func (s *SQLiteStorage) issueExists(ctx context.Context, id string) (bool, error) {
+	var found int
+	err := s.issueExistsStmt.QueryRowContext(ctx, id, s.opts.project, s.opts.project).Scan(&found)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve issue reference: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestWithIDChecksum(t *testing.T) {
+	store := setupTestDB(t, WithIDPrefix("bd"), WithIDChecksum())
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		issue := &types.Issue{Title: "Checked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	id := ids[1]
+	base, _, ok := splitChecksum(id)
+	if !ok || base != "bd-2" {
+		t.Fatalf("Expected the second ID to be bd-2 with a checksum suffix, got %s", id)
+	}
+	if !ValidateID(id) {
+		t.Errorf("Expected generated ID %s to validate", id)
+	}
+
+	// A mistyped digit or a swapped check character is caught
+	corrupted := strings.Replace(id, "-2-", "-3-", 1)
+	if ValidateID(corrupted) {
+		t.Errorf("Expected corrupted reference %s to fail validation", corrupted)
+	}
+	if ValidateID(base) {
+		t.Errorf("Expected %s without a suffix to fail validation", base)
+	}
+
+	// ResolveID accepts the full ID and the ID without its suffix
+	for _, ref := range []string{id, base} {
+		resolved, err := store.ResolveID(ctx, ref)
+		if err != nil {
+			t.Fatalf("ResolveID(%s) failed: %v", ref, err)
+		}
+		if resolved != id {
+			t.Errorf("Expected %s to resolve to %s, got %s", ref, id, resolved)
+		}
+	}
+	if _, err := store.ResolveID(ctx, corrupted); err == nil {
+		t.Errorf("Expected ResolveID to reject %s", corrupted)
+	}
+}
+
+func TestValidateIDDetectsAdjacentSwaps(t *testing.T) {
+	id := withChecksum("vc-1234")
+	if !ValidateID(id) {
+		t.Fatalf("Expected %s to validate", id)
+	}
+	if ValidateID(strings.Replace(id, "1234", "1324", 1)) {
+		t.Error("Expected a swap of adjacent digits to fail validation")
+	}
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// CloneIssue creates a new open, unassigned issue with the content of an existing one
+// (title, description, design, acceptance criteria, notes, priority, type and
+// estimates). Labels and "related" links are copied when requested in opts, in the
+// same transaction as the new issue. Blocking and parent-child dependencies are never
+// copied, so a clone doesn't silently join the source's dependency graph.
+func (s *SQLiteStorage) CloneIssue(ctx context.Context, sourceID string, opts types.CloneOptions, actor string) (*types.Issue, error) {
+	source, err := s.getIssue(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("issue %s: %w", sourceID, ErrIssueNotFound)
+	}
+
+	clone := &types.Issue{
+		Title:              source.Title,
+		Description:        source.Description,
+		Design:             source.Design,
+		AcceptanceCriteria: source.AcceptanceCriteria,
+		Notes:              source.Notes,
+		Status:             types.StatusOpen,
+		Priority:           source.Priority,
+		IssueType:          source.IssueType,
+		EstimatedMinutes:   source.EstimatedMinutes,
+		EstimateMinMinutes: source.EstimateMinMinutes,
+		EstimateMaxMinutes: source.EstimateMaxMinutes,
+	}
+
+	err = s.createIssue(ctx, clone, actor, func(conn *sql.Conn) error {
+		if opts.CopyLabels {
+			if err := copyLabels(ctx, conn, sourceID, clone.ID, actor, clone.CreatedAt); err != nil {
+				return err
+			}
+		}
+		if opts.CopyLinks {
+			if err := copyRelatedLinks(ctx, conn, sourceID, clone.ID, actor, clone.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone issue %s: %w", sourceID, err)
+	}
+
+	return clone, nil
+}
+
+// copyLabels gives the target issue all of the source's labels, recording the events
+// at createdAt
+func copyLabels(ctx context.Context, conn *sql.Conn, sourceID, targetID, actor string, createdAt time.Time) error {
+	if _, err := conn.ExecContext(ctx, `
+		INSERT OR IGNORE INTO labels (issue_id, label)
+		SELECT ?, label FROM labels WHERE issue_id = ?
+	`, targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to copy labels: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		SELECT ?, ?, ?, 'Added label: ' || label, ? FROM labels WHERE issue_id = ?
+	`, targetID, types.EventLabelAdded, actor, createdAt, sourceID); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
+}
+
+// copyRelatedLinks links the target to everything the source has a "related"
+// dependency with, in either direction. The links and their events are created at
+// createdAt, the clone's creation time.
+func copyRelatedLinks(ctx context.Context, conn *sql.Conn, sourceID, targetID, actor string, createdAt time.Time) error {
+	if _, err := conn.ExecContext(ctx, `
+		INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		SELECT ?, depends_on_id, type, ?, ? FROM dependencies
+		WHERE issue_id = ? AND type = ?
+	`, targetID, createdAt, actor, sourceID, types.DepRelated); err != nil {
+		return fmt.Errorf("failed to copy outgoing links: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		SELECT issue_id, ?, type, ?, ? FROM dependencies
+		WHERE depends_on_id = ? AND type = ?
+	`, targetID, createdAt, actor, sourceID, types.DepRelated); err != nil {
+		return fmt.Errorf("failed to copy incoming links: %w", err)
+	}
+
+	// The clone is brand new, so every link touching it was just copied
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		SELECT issue_id, ?, ?, 'Added dependency: ' || issue_id || ' ' || type || ' ' || depends_on_id, ?
+		FROM dependencies
+		WHERE (issue_id = ? OR depends_on_id = ?) AND type = ?
+	`, types.EventDependencyAdded, actor, createdAt, targetID, targetID, types.DepRelated); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestCloneIssue(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	store := setupTestDB(t, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	create := func(title string) *types.Issue {
+		issue := &types.Issue{Title: title, Description: "details", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeFeature, Assignee: "alice"}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	source := create("Source")
+	related := create("Related")
+	blocker := create("Blocker")
+
+	for _, label := range []string{"backend", "urgent"} {
+		if err := store.AddLabel(ctx, source.ID, label, "test"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: source.ID, DependsOnID: related.ID, Type: types.DepRelated},
+		{IssueID: source.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	now = now.Add(24 * time.Hour)
+	clone, err := store.CloneIssue(ctx, source.ID, types.CloneOptions{CopyLabels: true, CopyLinks: true}, "bob")
+	if err != nil {
+		t.Fatalf("CloneIssue failed: %v", err)
+	}
+	if clone.ID == source.ID {
+		t.Fatal("Expected clone to get a new ID")
+	}
+
+	got, err := store.GetIssue(ctx, clone.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Title != "Source" || got.Description != "details" || got.Priority != 1 || got.IssueType != types.TypeFeature {
+		t.Errorf("Expected content to be copied, got %+v", got)
+	}
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("Expected clone to be open and unassigned, got %s/%q", got.Status, got.Assignee)
+	}
+
+	labels, err := store.GetLabels(ctx, clone.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	sort.Strings(labels)
+	if len(labels) != 2 || labels[0] != "backend" || labels[1] != "urgent" {
+		t.Errorf("Expected clone to share the source's labels, got %v", labels)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, clone.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != related.ID || deps[0].Type != types.DepRelated {
+		t.Errorf("Expected only the related link to be copied, got %+v", deps)
+	} else if !deps[0].CreatedAt.Equal(clone.CreatedAt) || !clone.CreatedAt.Equal(now) {
+		t.Errorf("Expected the copied link to be created with the clone at %v, got %v", now, deps[0].CreatedAt)
+	}
+
+	// Without options only the content is copied
+	plain, err := store.CloneIssue(ctx, source.ID, types.CloneOptions{}, "bob")
+	if err != nil {
+		t.Fatalf("CloneIssue failed: %v", err)
+	}
+	labels, _ = store.GetLabels(ctx, plain.ID)
+	deps, _ = store.GetDependencyRecords(ctx, plain.ID)
+	if len(labels) != 0 || len(deps) != 0 {
+		t.Errorf("Expected no labels or links, got %v and %d links", labels, len(deps))
+	}
+
+	if _, err := store.CloneIssue(ctx, "vc-9999", types.CloneOptions{}, "bob"); err == nil {
+		t.Error("Expected error cloning a non-existent issue")
+	}
+}
@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// AddComment adds a comment to an issue. Unlike AddCommentWithID it accepts any
+// body, including an empty one, as it always has.
+func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	_, err := s.addComment(ctx, issueID, actor, comment)
+	return err
+}
+
+// AddCommentWithID adds a comment to an issue like AddComment and returns the new
+// comment's ID, for use with EditComment. The comment is stored in the comments
+// table and recorded as an EventCommented, and the issue's updated_at is bumped.
+// The commenter becomes a watcher of the issue unless disabled with
+// WithAutoWatchOnComment. The body must not be blank. Returns ErrIssueNotFound if
+// the issue doesn't exist.
+func (s *SQLiteStorage) AddCommentWithID(ctx context.Context, issueID, actor, body string) (string, error) {
+	if strings.TrimSpace(body) == "" {
+		return "", types.NewValidationError("body", body, types.ErrInvalidField, "comment body is required")
+	}
+	return s.addComment(ctx, issueID, actor, body)
+}
+
+// addComment implements AddComment and AddCommentWithID without validating body
+func (s *SQLiteStorage) addComment(ctx context.Context, issueID, actor, body string) (string, error) {
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now()
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO comments (issue_id, author, body, created_at)
+		VALUES (?, ?, ?, ?)
+	`, issueID, actor, body, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to add comment: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return "", fmt.Errorf("failed to get comment ID: %w", err)
+	}
+	commentID := strconv.FormatInt(id, 10)
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, issueID, types.EventCommented, actor, body, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to record event: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, now, issueID)
+	if err != nil {
+		return "", fmt.Errorf("failed to update timestamp: %w", err)
+	}
+
+	if !s.opts.skipCommentAutoWatch && strings.TrimSpace(actor) != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO watchers (issue_id, user, created_at)
+			VALUES (?, ?, ?)
+		`, issueID, actor, now)
+		if err != nil {
+			return "", fmt.Errorf("failed to add watcher: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit comment: %w", err)
+	}
+
+	s.notifyWatchers(ctx, issueID, types.EventCommented, actor)
+	return commentID, nil
+}
+
+// GetComments returns an issue's comments, oldest first. Comments added before the
+// comments table existed live only in the event history (see GetEventTimeline).
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) GetComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, author, body, created_at, edited_at
+		FROM comments
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var comments []*types.Comment
+	for rows.Next() {
+		var comment types.Comment
+		var id int64
+		var editedAt sql.NullTime
+		if err := rows.Scan(&id, &comment.IssueID, &comment.Author, &comment.Body, &comment.CreatedAt, &editedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.ID = strconv.FormatInt(id, 10)
+		if editedAt.Valid {
+			comment.EditedAt = &editedAt.Time
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// EditComment replaces a comment's body, keeping its created_at and setting
+// edited_at, and records an EventCommentEdited with the previous body as old_value
+func (s *SQLiteStorage) EditComment(ctx context.Context, commentID, newBody, actor string) error {
+	if strings.TrimSpace(newBody) == "" {
+		return types.NewValidationError("body", newBody, types.ErrInvalidField, "comment body is required")
+	}
+	id, err := strconv.ParseInt(commentID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("comment %s not found", commentID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var issueID, oldBody string
+	err = tx.QueryRowContext(ctx, `
+		SELECT c.issue_id, c.body FROM comments c JOIN issues i ON i.id = c.issue_id
+		WHERE c.id = ? AND (? = '' OR i.project_id = ?)
+	`, id, s.opts.project, s.opts.project).Scan(&issueID, &oldBody)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("comment %s not found", commentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE comments SET body = ?, edited_at = ? WHERE id = ?`, newBody, s.now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to edit comment: %w", err)
+	}
+
+	oldData, err := json.Marshal(map[string]string{"body": oldBody})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, comment, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, issueID, types.EventCommentEdited, actor, string(oldData), newBody, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestComments(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Commented", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	firstID, err := store.AddCommentWithID(ctx, issue.ID, "alice", "first")
+	if err != nil {
+		t.Fatalf("AddCommentWithID failed: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "bob", "second"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := store.AddCommentWithID(ctx, issue.ID, "alice", "  "); err == nil {
+		t.Error("Expected an error for an empty comment body")
+	}
+	if _, err := store.AddCommentWithID(ctx, "test-999", "alice", "hello"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound commenting on a missing issue, got %v", err)
+	}
+	if err := store.EditComment(ctx, firstID, "", "alice"); err == nil {
+		t.Error("Expected an error editing a comment to an empty body")
+	}
+
+	comments, err := store.GetComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	if len(comments) != 2 || comments[0].ID != firstID || comments[0].Body != "first" || comments[1].Author != "bob" {
+		t.Fatalf("Unexpected comments: %+v", comments)
+	}
+	if comments[0].EditedAt != nil {
+		t.Error("Expected a new comment to have no edited_at")
+	}
+
+	if err := store.EditComment(ctx, firstID, "first, revised", "alice"); err != nil {
+		t.Fatalf("EditComment failed: %v", err)
+	}
+	if err := store.EditComment(ctx, "9999", "nope", "alice"); err == nil {
+		t.Error("Expected an error editing a missing comment")
+	}
+
+	comments, _ = store.GetComments(ctx, issue.ID)
+	edited := comments[0]
+	if edited.Body != "first, revised" || edited.EditedAt == nil {
+		t.Errorf("Expected the edited body and edited_at, got %+v", edited)
+	}
+
+	timeline, err := store.GetEventTimeline(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetEventTimeline failed: %v", err)
+	}
+	var commented, editEvents int
+	for _, e := range timeline {
+		switch e.EventType {
+		case types.EventCommented:
+			commented++
+		case types.EventCommentEdited:
+			editEvents++
+			if e.OldValue == nil || *e.OldValue != `{"body":"first"}` {
+				t.Errorf("Expected the previous body in old_value, got %v", e.OldValue)
+			}
+		}
+	}
+	if commented != 2 || editEvents != 1 {
+		t.Errorf("Expected 2 commented and 1 edited event, got %d and %d", commented, editEvents)
+	}
+
+	// Comments travel with bundles
+	var buf bytes.Buffer
+	if err := store.ExportBundle(ctx, &buf); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	dst := setupTestDB(t)
+	if err := dst.ImportBundle(ctx, &buf, "importer"); err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+	imported, err := dst.GetComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	if len(imported) != 2 || imported[0].Body != "first, revised" || imported[0].EditedAt == nil {
+		t.Errorf("Unexpected imported comments: %+v", imported)
+	}
+}
+
+// TestAddCommentAcceptsEmptyBody verifies AddComment keeps accepting any body, as
+// it did before AddCommentWithID's validation was added
+func TestAddCommentAcceptsEmptyBody(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Commented", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "alice", ""); err != nil {
+		t.Fatalf("Expected AddComment to accept an empty body, got %v", err)
+	}
+	comments, _ := store.GetComments(ctx, issue.ID)
+	if len(comments) != 1 || comments[0].Body != "" {
+		t.Errorf("Expected one empty comment, got %+v", comments)
+	}
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// dsnParams are the connection parameters shared by the primary and mirror databases
+const dsnParams = "?_journal_mode=WAL&_foreign_keys=ON"
+
+// connector opens connections wrapped for slow query logging (WithSlowQueryThreshold)
+// and write mirroring (WithMirror). It is only used when one of them is enabled, so
+// the default path talks to the driver directly.
+type connector struct {
+	dsn  string
+	opts *options
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	primary, err := c.open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	wrapped := &conn{SQLiteConn: primary, opts: c.opts}
+
+	if c.opts.mirrorPath != "" {
+		// A mirror that can't be opened leaves this connection unmirrored rather
+		// than failing the primary
+		mirror, err := c.open(c.opts.mirrorPath + dsnParams)
+		if err != nil {
+			c.opts.warnf("failed to open mirror database %s: %v", c.opts.mirrorPath, err)
+		} else {
+			wrapped.mirror = mirror
+		}
+	}
+	return wrapped, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return &sqlite3.SQLiteDriver{ConnectHook: registerFunctions}
+}
+
+func (c *connector) open(dsn string) (*sqlite3.SQLiteConn, error) {
+	dc, err := c.Driver().Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	sqliteConn, ok := dc.(*sqlite3.SQLiteConn)
+	if !ok {
+		_ = dc.Close()
+		return nil, fmt.Errorf("unexpected connection type %T", dc)
+	}
+	return sqliteConn, nil
+}
+
+// conn is a driver connection with optional statement timing and write mirroring.
+// database/sql runs Exec and Query calls, including those inside transactions,
+// directly on the connection, so these hooks see every statement.
+type conn struct {
+	*sqlite3.SQLiteConn
+	opts   *options
+	mirror *sqlite3.SQLiteConn // nil unless mirroring
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := c.SQLiteConn.ExecContext(ctx, query, args)
+	c.checkSlow(query, time.Since(start))
+	if err == nil {
+		c.mirrorExec(query, args)
+	}
+	return result, err
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.opts.slowQueryThreshold <= 0 {
+		return c.SQLiteConn.QueryContext(ctx, query, args)
+	}
+	return c.timedQuery(ctx, query, args)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.SQLiteConn.BeginTx(ctx, opts)
+	if err != nil || c.mirror == nil {
+		return tx, err
+	}
+	c.mirrorExec("BEGIN", nil)
+	return &mirroredTx{Tx: tx, conn: c}, nil
+}
+
+func (c *conn) Close() error {
+	if c.mirror != nil {
+		if err := c.mirror.Close(); err != nil {
+			c.opts.warnf("failed to close mirror database: %v", err)
+		}
+	}
+	return c.SQLiteConn.Close()
+}
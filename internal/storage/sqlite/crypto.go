@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// encryptedPrefix marks column values written by WithColumnEncryption, so rows
+// written before encryption was enabled can still be read as plaintext
+const encryptedPrefix = "enc:v1:"
+
+// encryptableColumns are the free-text issue columns WithColumnEncryption accepts.
+// Title stays plaintext: it is length-checked by the schema and used for search.
+var encryptableColumns = map[string]bool{
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+}
+
+// columnCipher encrypts and decrypts the configured issue columns with AES-GCM.
+// A nil *columnCipher is valid and leaves every value unchanged.
+type columnCipher struct {
+	aead    cipher.AEAD
+	columns map[string]bool
+}
+
+func newColumnCipher(key []byte, columns []string) (*columnCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	c := &columnCipher{aead: aead, columns: make(map[string]bool, len(columns))}
+	for _, column := range columns {
+		c.columns[column] = true
+	}
+	return c, nil
+}
+
+// encrypts reports whether column is stored encrypted
+func (c *columnCipher) encrypts(column string) bool {
+	return c != nil && c.columns[column]
+}
+
+// encrypt returns the stored form of a column value. Empty values are stored as is.
+func (c *columnCipher) encrypt(column, value string) (string, error) {
+	if !c.encrypts(column) || value == "" {
+		return value, nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the plaintext of a stored value. Values without the encrypted
+// prefix, and any value when no key is configured, are returned unchanged.
+func (c *columnCipher) decrypt(value string) (string, error) {
+	if c == nil || !strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value (wrong key?): %w", err)
+	}
+	return string(plain), nil
+}
+
+// issueTextFields pairs the encryptable columns with the issue's fields
+func issueTextFields(issue *types.Issue) map[string]*string {
+	return map[string]*string{
+		"description":         &issue.Description,
+		"design":              &issue.Design,
+		"acceptance_criteria": &issue.AcceptanceCriteria,
+		"notes":               &issue.Notes,
+	}
+}
+
+// encryptIssue returns the issue as it is stored: a copy with the configured
+// columns encrypted, or issue itself when nothing is encrypted
+func (c *columnCipher) encryptIssue(issue *types.Issue) (*types.Issue, error) {
+	if c == nil {
+		return issue, nil
+	}
+	stored := *issue
+	for column, field := range issueTextFields(&stored) {
+		encrypted, err := c.encrypt(column, *field)
+		if err != nil {
+			return nil, err
+		}
+		*field = encrypted
+	}
+	return &stored, nil
+}
+
+// decryptIssue decrypts the issue's stored text fields in place
+func (c *columnCipher) decryptIssue(issue *types.Issue) error {
+	if c == nil {
+		return nil
+	}
+	for column, field := range issueTextFields(issue) {
+		plain, err := c.decrypt(*field)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s of %s: %w", column, issue.ID, err)
+		}
+		*field = plain
+	}
+	return nil
+}
+
+// encryptUpdates returns the updates as they are stored: a copy with string values
+// for encrypted columns encrypted, or updates itself when nothing is encrypted
+func (c *columnCipher) encryptUpdates(updates map[string]interface{}) (map[string]interface{}, error) {
+	if c == nil {
+		return updates, nil
+	}
+	stored := make(map[string]interface{}, len(updates))
+	for key, value := range updates {
+		if text, ok := value.(string); ok && c.encrypts(key) {
+			encrypted, err := c.encrypt(key, text)
+			if err != nil {
+				return nil, err
+			}
+			value = encrypted
+		}
+		stored[key] = value
+	}
+	return stored, nil
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestWithColumnEncryption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vc.db")
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{7}, 32)
+
+	store, err := New(path, WithColumnEncryption(key, "description", "notes"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	const secret = "Rotate the production API token"
+	issue := &types.Issue{Title: "Credentials", Description: secret, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "token in vault"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	// The raw columns, and the event snapshots, hold ciphertext
+	var description, notes string
+	if err := store.db.QueryRow(`SELECT description, notes FROM issues WHERE id = ?`, issue.ID).Scan(&description, &notes); err != nil {
+		t.Fatalf("Failed to read raw columns: %v", err)
+	}
+	for _, raw := range []string{description, notes} {
+		if !strings.HasPrefix(raw, encryptedPrefix) || strings.Contains(raw, "token") {
+			t.Errorf("Expected an encrypted column value, got %q", raw)
+		}
+	}
+	var leaked int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM events WHERE new_value LIKE '%token%' OR old_value LIKE '%token%'`).Scan(&leaked); err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if leaked != 0 {
+		t.Errorf("Expected no plaintext in events, found %d", leaked)
+	}
+
+	// Reads decrypt transparently
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Description != secret || got.Notes != "token in vault" {
+		t.Errorf("Expected decrypted values, got %q and %q", got.Description, got.Notes)
+	}
+
+	// Search can't see into encrypted descriptions, only titles
+	results, err := store.SearchIssues(ctx, "production", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no matches in encrypted description, got %d", len(results))
+	}
+	results, err = store.SearchIssues(ctx, "Credentials", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Description != secret {
+		t.Errorf("Expected the issue to match by title with a decrypted description, got %+v", results)
+	}
+}
+
+func TestWithColumnEncryptionValidation(t *testing.T) {
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{7}, 32)
+
+	if _, err := New(filepath.Join(dir, "short.db"), WithColumnEncryption([]byte("short"), "description")); err == nil {
+		t.Error("Expected an error for a bad key length")
+	}
+	if _, err := New(filepath.Join(dir, "title.db"), WithColumnEncryption(key, "title")); err == nil {
+		t.Error("Expected an error for a column that can't be encrypted")
+	}
+	if _, err := New(filepath.Join(dir, "none.db"), WithColumnEncryption(key)); err == nil {
+		t.Error("Expected an error when no columns are given")
+	}
+}
@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// DeleteIssue permanently deletes an issue. Its labels, dependencies in either
+// direction, votes, watchers, flags, checklist, reminders and execution state are
+// removed with it. The event history, ending with an EventDeleted by actor, is
+// moved to the deleted events archive (see GetDeletedIssueEvents) so the audit
+// trail survives. Use DeleteIssueWithOptions with Purge to erase the history as well.
+func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string, actor string) error {
+	return s.DeleteIssueWithOptions(ctx, id, types.DeleteOptions{}, actor)
+}
+
+// DeleteIssueWithOptions deletes an issue like DeleteIssue, with opts choosing
+// whether the event history is archived or purged. Everything happens in one
+// transaction, so a failure leaves the issue untouched.
+func (s *SQLiteStorage) DeleteIssueWithOptions(ctx context.Context, id string, opts types.DeleteOptions, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM issues WHERE id = ? AND (? = '' OR project_id = ?))
+	`, id, s.opts.project, s.opts.project).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check issue %s: %w", id, err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, created_at)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventDeleted, actor, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if opts.Purge {
+		// Agent events have no foreign key, so the cascade below won't reach them
+		if _, err := tx.ExecContext(ctx, `DELETE FROM agent_events WHERE issue_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete agent events: %w", err)
+		}
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO deleted_events (id, issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+			FROM events WHERE issue_id = ?
+		`, id)
+		if err != nil {
+			return fmt.Errorf("failed to archive events: %w", err)
+		}
+	}
+
+	// Foreign keys cascade the delete to the events and other dependent rows
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issues WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete issue: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetDeletedIssueEvents returns the archived event history of an issue removed by
+// DeleteIssue, oldest first. Purged and never-deleted issues have none.
+func (s *SQLiteStorage) GetDeletedIssueEvents(ctx context.Context, issueID string) ([]*types.Event, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM deleted_events
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted issue events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueEvents(rows)
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/events"
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestDeleteIssueKeepsAuditTrail(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	doomed := &types.Issue{Title: "Doomed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	survivor := &types.Issue{Title: "Survivor", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{doomed, survivor} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, doomed.ID, "gdpr", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: survivor.ID, DependsOnID: doomed.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	if err := store.DeleteIssue(ctx, doomed.ID, "admin"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	if got, err := store.GetIssue(ctx, doomed.ID); err != nil || got != nil {
+		t.Errorf("Expected issue to be gone, got %v, %v", got, err)
+	}
+	if deps, _ := store.GetDependencyRecords(ctx, survivor.ID); len(deps) != 0 {
+		t.Errorf("Expected dependency on deleted issue to be removed, got %d", len(deps))
+	}
+	if events, _ := store.GetEvents(ctx, doomed.ID, 0); len(events) != 0 {
+		t.Errorf("Expected no live events for deleted issue, got %d", len(events))
+	}
+
+	archived, err := store.GetDeletedIssueEvents(ctx, doomed.ID)
+	if err != nil {
+		t.Fatalf("GetDeletedIssueEvents failed: %v", err)
+	}
+	if len(archived) < 2 || archived[0].EventType != types.EventCreated {
+		t.Fatalf("Expected archived history starting with creation, got %d events", len(archived))
+	}
+	last := archived[len(archived)-1]
+	if last.EventType != types.EventDeleted || last.Actor != "admin" {
+		t.Errorf("Expected history to end with a deleted event by admin, got %s by %s", last.EventType, last.Actor)
+	}
+
+	if err := store.DeleteIssue(ctx, doomed.ID, "admin"); err == nil {
+		t.Error("Expected error deleting a non-existent issue")
+	}
+}
+
+func TestDeleteIssuePurge(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Forget me", Description: "personal data", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	err := store.StoreAgentEvent(ctx, &events.AgentEvent{
+		ID:        "evt-1",
+		Type:      events.EventTypeProgress,
+		Timestamp: time.Now(),
+		IssueID:   issue.ID,
+		Severity:  events.SeverityInfo,
+		Message:   "working on personal data",
+	})
+	if err != nil {
+		t.Fatalf("StoreAgentEvent failed: %v", err)
+	}
+
+	if err := store.DeleteIssueWithOptions(ctx, issue.ID, types.DeleteOptions{Purge: true}, "admin"); err != nil {
+		t.Fatalf("DeleteIssueWithOptions failed: %v", err)
+	}
+
+	if archived, _ := store.GetDeletedIssueEvents(ctx, issue.ID); len(archived) != 0 {
+		t.Errorf("Expected no archived events after purge, got %d", len(archived))
+	}
+	if agentEvents, _ := store.GetAgentEventsByIssue(ctx, issue.ID); len(agentEvents) != 0 {
+		t.Errorf("Expected agent events to be purged, got %d", len(agentEvents))
+	}
+}
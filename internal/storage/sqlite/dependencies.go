@@ -10,28 +10,29 @@ import (
 	"github.com/steveyegge/vc/internal/types"
 )
 
-// AddDependency adds a dependency between issues with cycle prevention
+// AddDependency adds a dependency between issues with cycle prevention.
+// Adding an edge that already exists with the same type is a no-op.
 func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	// Validate that both issues exist
-	issueExists, err := s.GetIssue(ctx, dep.IssueID)
+	issueExists, err := s.getIssue(ctx, dep.IssueID)
 	if err != nil {
 		return fmt.Errorf("failed to check issue %s: %w", dep.IssueID, err)
 	}
 	if issueExists == nil {
-		return fmt.Errorf("issue %s not found", dep.IssueID)
+		return fmt.Errorf("issue %s: %w", dep.IssueID, ErrIssueNotFound)
 	}
 
-	dependsOnExists, err := s.GetIssue(ctx, dep.DependsOnID)
+	dependsOnExists, err := s.getIssue(ctx, dep.DependsOnID)
 	if err != nil {
 		return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
 	}
 	if dependsOnExists == nil {
-		return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+		return fmt.Errorf("dependency target %s: %w", dep.DependsOnID, ErrIssueNotFound)
 	}
 
 	// Prevent self-dependency
 	if dep.IssueID == dep.DependsOnID {
-		return fmt.Errorf("issue cannot depend on itself")
+		return types.NewValidationError("depends_on_id", dep.DependsOnID, types.ErrInvalidField, "issue cannot depend on itself")
 	}
 
 	dep.CreatedAt = time.Now()
@@ -48,6 +49,20 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
 		VALUES (?, ?, ?, ?, ?)
 	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+	if isUniqueConstraintError(err) {
+		// Re-adding an existing edge is a no-op; a different type for the same
+		// pair is still a conflict the caller should resolve
+		var existingType types.DependencyType
+		if qerr := tx.QueryRowContext(ctx, `
+			SELECT type FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+		`, dep.IssueID, dep.DependsOnID).Scan(&existingType); qerr != nil {
+			return fmt.Errorf("failed to check existing dependency: %w", qerr)
+		}
+		if existingType != dep.Type {
+			return fmt.Errorf("dependency %s → %s already exists with type %s", dep.IssueID, dep.DependsOnID, existingType)
+		}
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to add dependency: %w", err)
 	}
@@ -89,17 +104,17 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		}
 
 		if cycleExists {
-			return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
-				dep.IssueID, dep.DependsOnID, dep.IssueID)
+			return fmt.Errorf("cannot add dependency %s → %s → ... → %s: %w",
+				dep.IssueID, dep.DependsOnID, dep.IssueID, ErrCycleDetected)
 		}
 	}
 
 	// Record event
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
 	`, dep.IssueID, types.EventDependencyAdded, actor,
-		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
+		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID), s.now())
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -123,10 +138,10 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
 	`, issueID, types.EventDependencyRemoved, actor,
-		fmt.Sprintf("Removed dependency on %s", dependsOnID))
+		fmt.Sprintf("Removed dependency on %s", dependsOnID), s.now())
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -137,28 +152,25 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 // GetDependencies returns issues that this issue depends on
 func (s *SQLiteStorage) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
-		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at
+		SELECT `+issueColumns+`
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
 		WHERE d.issue_id = ?
+		  AND (? = '' OR (i.project_id = ? AND d.issue_id IN (SELECT id FROM issues WHERE project_id = ?)))
 		ORDER BY i.priority ASC
-	`, issueID)
+	`, issueID, s.opts.project, s.opts.project, s.opts.project)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependencies: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	return scanIssues(rows)
+	return s.scanIssues(rows)
 }
 
 // GetDependents returns issues that depend on this issue
 func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
-		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at
+		SELECT `+issueColumns+`
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -169,7 +181,76 @@ func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	}
 	defer func() { _ = rows.Close() }()
 
-	return scanIssues(rows)
+	return s.scanIssues(rows)
+}
+
+// GetBlockers returns the issues that block issueID through blocks dependencies,
+// whatever their status. Unlike GetDependencies, related and parent-child links are
+// not included.
+func (s *SQLiteStorage) GetBlockers(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN dependencies d ON i.id = d.depends_on_id
+		WHERE d.issue_id = ? AND d.type = ?
+		ORDER BY i.priority ASC, i.id ASC
+	`, issueID, types.DepBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
+
+// GetBlocked returns the issues that issueID blocks through blocks dependencies,
+// whatever their status. GetImpact follows the chain transitively.
+func (s *SQLiteStorage) GetBlocked(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		WHERE d.depends_on_id = ? AND d.type = ?
+		ORDER BY i.priority ASC, i.id ASC
+	`, issueID, types.DepBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
+
+// GetImpact returns every issue blocked by id, directly or through a chain of
+// blocking dependencies: everything a delay to id would hold up. Each issue is
+// listed once even if reachable by several paths, and cycles terminate because
+// the recursion only follows issues it hasn't seen.
+func (s *SQLiteStorage) GetImpact(ctx context.Context, id string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		WITH RECURSIVE impacted(id) AS (
+			SELECT d.issue_id
+			FROM dependencies d
+			WHERE d.depends_on_id = ? AND d.type = ?
+
+			UNION
+
+			SELECT d.issue_id
+			FROM dependencies d
+			JOIN impacted im ON d.depends_on_id = im.id
+			WHERE d.type = ?
+		)
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN impacted im ON im.id = i.id
+		WHERE i.id != ?
+		ORDER BY i.priority ASC, i.id ASC
+	`, id, types.DepBlocks, types.DepBlocks, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get impact of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
 }
 
 // GetDependencyRecords returns the raw dependency records for an issue
@@ -274,6 +355,9 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		if assignee.Valid {
 			node.Assignee = assignee.String
 		}
+		if err := s.cipher.decryptIssue(&node.Issue); err != nil {
+			return nil, err
+		}
 
 		node.Truncated = node.Depth == maxDepth
 
@@ -346,7 +430,7 @@ func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, err
 		// Fetch full issue details for each ID in the cycle
 		var cycleIssues []*types.Issue
 		for _, issueID := range issueIDs {
-			issue, err := s.GetIssue(ctx, issueID)
+			issue, err := s.getIssue(ctx, issueID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
 			}
@@ -363,38 +447,40 @@ func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, err
 	return cycles, nil
 }
 
-// Helper function to scan issues from rows
-func scanIssues(rows *sql.Rows) ([]*types.Issue, error) {
-	var issues []*types.Issue
-	for rows.Next() {
-		var issue types.Issue
-		var closedAt sql.NullTime
-		var estimatedMinutes sql.NullInt64
-		var assignee sql.NullString
+// SearchIssuesWithCounts finds issues like SearchIssues, with each issue's open
+// blocker, open blocked and direct subtask counts computed in the same query
+func (s *SQLiteStorage) SearchIssuesWithCounts(ctx context.Context, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error) {
+	columns := issueColumns + `,
+		(SELECT COUNT(*) FROM dependencies d JOIN issues b ON b.id = d.depends_on_id
+		 WHERE d.issue_id = i.id AND d.type = 'blocks' AND b.status != 'closed'),
+		(SELECT COUNT(*) FROM dependencies d JOIN issues b ON b.id = d.issue_id
+		 WHERE d.depends_on_id = i.id AND d.type = 'blocks' AND b.status != 'closed'),
+		(SELECT COUNT(*) FROM dependencies d
+		 WHERE d.depends_on_id = i.id AND d.type = 'parent-child')`
+	querySQL, args, err := s.searchIssuesSQL(columns, query, filter)
+	if err != nil {
+		return nil, err
+	}
 
-		err := rows.Scan(
-			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
-			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt,
-		)
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*types.IssueWithCounts
+	for rows.Next() {
+		var result types.IssueWithCounts
+		issue, err := s.scanIssue(rows, &result.BlockerCount, &result.BlockedCount, &result.ChildCount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
 		}
-
-		if closedAt.Valid {
-			issue.ClosedAt = &closedAt.Time
-		}
-		if estimatedMinutes.Valid {
-			mins := int(estimatedMinutes.Int64)
-			issue.EstimatedMinutes = &mins
-		}
-		if assignee.Valid {
-			issue.Assignee = assignee.String
-		}
-
-		issues = append(issues, &issue)
+		result.Issue = *issue
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating issues: %w", err)
 	}
 
-	return issues, nil
+	return results, nil
 }
@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestAddDependencyIdempotent(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, blocked} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		dep := &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency attempt %d failed: %v", i+1, err)
+		}
+	}
+
+	records, err := store.GetDependencyRecords(ctx, blocked.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected 1 dependency after adding twice, got %d", len(records))
+	}
+
+	events, err := store.GetEvents(ctx, blocked.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	added := 0
+	for _, e := range events {
+		if e.EventType == types.EventDependencyAdded {
+			added++
+		}
+	}
+	if added != 1 {
+		t.Errorf("Expected 1 dependency_added event, got %d", added)
+	}
+
+	// The same pair with a different type is a conflict, not a no-op
+	dep := &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepRelated}
+	if err := store.AddDependency(ctx, dep, "test"); err == nil {
+		t.Error("Expected error re-adding the pair with a different type")
+	}
+}
+
+func TestGetImpact(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"A", "B", "C", "Unrelated", "Related to C"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	a, b, c := issues[0], issues[1], issues[2]
+
+	// A blocks B, B blocks C; a related link doesn't carry impact
+	for _, dep := range []*types.Dependency{
+		{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+		{IssueID: c.ID, DependsOnID: b.ID, Type: types.DepBlocks},
+		{IssueID: issues[4].ID, DependsOnID: c.ID, Type: types.DepRelated},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	impact, err := store.GetImpact(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetImpact failed: %v", err)
+	}
+	if len(impact) != 2 || impact[0].ID != b.ID || impact[1].ID != c.ID {
+		ids := make([]string, len(impact))
+		for i, issue := range impact {
+			ids[i] = issue.ID
+		}
+		t.Errorf("Expected impact of A to be B and C, got %v", ids)
+	}
+
+	impact, err = store.GetImpact(ctx, c.ID)
+	if err != nil || len(impact) != 0 {
+		t.Errorf("Expected nothing blocked by C, got %d issues, %v", len(impact), err)
+	}
+}
+
+func TestSearchIssuesWithCounts(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"Epic", "Subtask 1", "Subtask 2", "Subtask 3", "Blocker", "Done blocker"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	epic, blocker, doneBlocker := issues[0], issues[4], issues[5]
+
+	// Three subtasks; the epic is blocked by one open and one closed issue
+	deps := []*types.Dependency{
+		{IssueID: epic.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+		{IssueID: epic.ID, DependsOnID: doneBlocker.ID, Type: types.DepBlocks},
+	}
+	for _, sub := range issues[1:4] {
+		deps = append(deps, &types.Dependency{IssueID: sub.ID, DependsOnID: epic.ID, Type: types.DepParentChild})
+	}
+	for _, dep := range deps {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, doneBlocker.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	results, err := store.SearchIssuesWithCounts(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesWithCounts failed: %v", err)
+	}
+	if len(results) != len(issues) {
+		t.Fatalf("Expected %d issues, got %d", len(issues), len(results))
+	}
+
+	byID := make(map[string]*types.IssueWithCounts)
+	for _, result := range results {
+		byID[result.ID] = result
+	}
+	if got := byID[epic.ID]; got.ChildCount != 3 || got.BlockerCount != 1 || got.BlockedCount != 0 {
+		t.Errorf("Expected epic with 3 subtasks blocked by 1, got %+v", got)
+	}
+	if got := byID[blocker.ID]; got.BlockedCount != 1 || got.BlockerCount != 0 || got.ChildCount != 0 {
+		t.Errorf("Expected blocker to block 1 issue, got %+v", got)
+	}
+	if got := byID[issues[1].ID]; got.ChildCount != 0 || got.BlockerCount != 0 || got.BlockedCount != 0 {
+		t.Errorf("Expected no counts on a subtask, got %+v", got)
+	}
+}
+
+func TestBlockingRelationships(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"Blocker", "Blocked", "Free", "Child"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	blocker, blocked, free, child := issues[0], issues[1], issues[2], issues[3]
+
+	for _, dep := range []*types.Dependency{
+		{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+		{IssueID: child.ID, DependsOnID: blocker.ID, Type: types.DepParentChild},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	// Self-references and cycles are rejected
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: free.ID, DependsOnID: free.ID, Type: types.DepBlocks}, "test"); err == nil {
+		t.Error("Expected a self-dependency to be rejected")
+	}
+	err := store.AddDependency(ctx, &types.Dependency{IssueID: blocker.ID, DependsOnID: blocked.ID, Type: types.DepBlocks}, "test")
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("Expected ErrCycleDetected, got %v", err)
+	}
+
+	// Only blocks links count
+	blockers, err := store.GetBlockers(ctx, blocked.ID)
+	if err != nil {
+		t.Fatalf("GetBlockers failed: %v", err)
+	}
+	if len(blockers) != 1 || blockers[0].ID != blocker.ID {
+		t.Errorf("Expected %s to be blocked by %s, got %v", blocked.ID, blocker.ID, blockers)
+	}
+	blockedBy, err := store.GetBlocked(ctx, blocker.ID)
+	if err != nil {
+		t.Fatalf("GetBlocked failed: %v", err)
+	}
+	if len(blockedBy) != 1 || blockedBy[0].ID != blocked.ID {
+		t.Errorf("Expected %s to block only %s, got %v", blocker.ID, blocked.ID, blockedBy)
+	}
+
+	actionable := func() map[string]bool {
+		t.Helper()
+		results, err := store.SearchIssues(ctx, "", types.IssueFilter{NoOpenBlockers: true})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, issue := range results {
+			ids[issue.ID] = true
+		}
+		return ids
+	}
+	if got := actionable(); got[blocked.ID] || !got[free.ID] || !got[child.ID] || !got[blocker.ID] {
+		t.Errorf("Expected everything but %s to be actionable, got %v", blocked.ID, got)
+	}
+
+	// Closing the blocker frees the blocked issue
+	if err := store.CloseIssue(ctx, blocker.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if got := actionable(); !got[blocked.ID] {
+		t.Errorf("Expected %s to be actionable once its blocker closed, got %v", blocked.ID, got)
+	}
+
+	if err := store.RemoveDependency(ctx, blocked.ID, blocker.ID, "test"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if blockers, _ := store.GetBlockers(ctx, blocked.ID); len(blockers) != 0 {
+		t.Errorf("Expected no blockers after removal, got %v", blockers)
+	}
+}
@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// changedUpdates returns a copy of updates without the fields whose new value equals
+// the issue's current value. Fields that can't be compared (including unknown ones,
+// which UpdateIssue rejects) are kept.
+func changedUpdates(issue *types.Issue, updates map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{}, len(updates))
+	for field, value := range updates {
+		current, ok := currentFieldValue(issue, field)
+		if ok && sameFieldValue(current, value) {
+			continue
+		}
+		changed[field] = value
+	}
+	return changed
+}
+
+// currentFieldValue returns the issue's value for an updatable field
+func currentFieldValue(issue *types.Issue, field string) (interface{}, bool) {
+	switch field {
+	case "status":
+		return string(issue.Status), true
+	case "priority":
+		return issue.Priority, true
+	case "title":
+		return issue.Title, true
+	case "assignee":
+		return issue.Assignee, true
+	case "description":
+		return issue.Description, true
+	case "design":
+		return issue.Design, true
+	case "acceptance_criteria":
+		return issue.AcceptanceCriteria, true
+	case "notes":
+		return issue.Notes, true
+	case "issue_type":
+		return string(issue.IssueType), true
+	case "estimated_minutes":
+		return issue.EstimatedMinutes, true
+	case "estimate_min_minutes":
+		return issue.EstimateMinMinutes, true
+	case "estimate_max_minutes":
+		return issue.EstimateMaxMinutes, true
+	case "reviewer":
+		return issue.Reviewer, true
+	case "due_at":
+		return issue.DueAt, true
+	case "approved_at":
+		return issue.ApprovedAt, true
+	case "approved_by":
+		return issue.ApprovedBy, true
+	}
+	return nil, false
+}
+
+// sameFieldValue compares a current field value with an update value, accepting
+// the types callers pass for each field (e.g. types.Status or string, int or *int)
+func sameFieldValue(current, update interface{}) bool {
+	switch c := current.(type) {
+	case string:
+		switch u := update.(type) {
+		case string:
+			return c == u
+		case types.Status:
+			return c == string(u)
+		case types.IssueType:
+			return c == string(u)
+		}
+	case int:
+		switch u := update.(type) {
+		case int:
+			return c == u
+		case int64:
+			return int64(c) == u
+		}
+	case *int:
+		switch u := update.(type) {
+		case nil:
+			return c == nil
+		case *int:
+			return (c == nil && u == nil) || (c != nil && u != nil && *c == *u)
+		case int:
+			return c != nil && *c == u
+		}
+	case *time.Time:
+		switch u := update.(type) {
+		case nil:
+			return c == nil
+		case *time.Time:
+			return (c == nil && u == nil) || (c != nil && u != nil && c.Equal(*u))
+		case time.Time:
+			return c != nil && c.Equal(u)
+		}
+	}
+	return false
+}
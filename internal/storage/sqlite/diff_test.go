@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestUpdateIssueOnlyWritesChangedFields(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:            "Diffed",
+		Description:      "same",
+		Status:           types.StatusOpen,
+		Priority:         2,
+		IssueType:        types.TypeTask,
+		EstimatedMinutes: intPtr(30),
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Half of the map matches the current values
+	changed, err := store.UpdateIssueChanged(ctx, issue.ID, map[string]interface{}{
+		"title":             "Diffed",
+		"description":       "same",
+		"status":            types.StatusOpen,
+		"estimated_minutes": 30,
+		"priority":          1,
+		"notes":             "new notes",
+	}, "test")
+	if err != nil {
+		t.Fatalf("UpdateIssueChanged failed: %v", err)
+	}
+	if !changed {
+		t.Error("Expected the update to report a change")
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var update *types.Event
+	for _, e := range events {
+		if e.EventType == types.EventUpdated {
+			update = e
+		}
+	}
+	if update == nil || update.NewValue == nil {
+		t.Fatalf("Expected an update event with new_value, got %+v", events)
+	}
+	var recorded map[string]interface{}
+	if err := json.Unmarshal([]byte(*update.NewValue), &recorded); err != nil {
+		t.Fatalf("Failed to parse new_value: %v", err)
+	}
+	if len(recorded) != 2 || recorded["notes"] != "new notes" || recorded["priority"] != float64(1) {
+		t.Errorf("Expected only priority and notes in the event, got %v", recorded)
+	}
+
+	// Nothing changed: no write, no event
+	before, _ := store.GetIssue(ctx, issue.ID)
+	changed, err = store.UpdateIssueChanged(ctx, issue.ID, map[string]interface{}{
+		"priority":          1,
+		"estimated_minutes": intPtr(30),
+	}, "test")
+	if err != nil {
+		t.Fatalf("UpdateIssueChanged failed: %v", err)
+	}
+	if changed {
+		t.Error("Expected a no-op update to report no change")
+	}
+	after, _ := store.GetIssue(ctx, issue.ID)
+	if !after.UpdatedAt.Equal(before.UpdatedAt) {
+		t.Error("Expected updated_at to be untouched by a no-op update")
+	}
+	eventsAfter, _ := store.GetEvents(ctx, issue.ID, 0)
+	if len(eventsAfter) != len(events) {
+		t.Errorf("Expected no new events, got %d more", len(eventsAfter)-len(events))
+	}
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import "errors"
+
+// ErrAssigneeRequired is returned when a status transition requires an assignee
+// (see WithAssigneeRequired) and the issue has none
+var ErrAssigneeRequired = errors.New("assignee required")
+
+// ErrWIPLimitExceeded is returned when moving work to an assignee who already has as
+// many in-progress issues as their WIP limit allows (see WithWIPLimit)
+var ErrWIPLimitExceeded = errors.New("WIP limit exceeded")
+
+// ErrChecklistIncomplete is returned when closing an issue whose checklist has
+// unfinished items (see WithChecklistGatedClose)
+var ErrChecklistIncomplete = errors.New("checklist incomplete")
+
+// ErrUnknownPlaceholder is returned when a template with StrictPlaceholders set
+// uses a placeholder CreateIssueFromTemplate doesn't know
+var ErrUnknownPlaceholder = errors.New("unknown template placeholder")
+
+// ErrNotClosed is returned when reopening an issue that isn't closed
+var ErrNotClosed = errors.New("issue is not closed")
+
+// ErrConcurrentModification is returned by UpdateIssueIfUnchanged when the issue was
+// updated after the caller read it
+var ErrConcurrentModification = errors.New("issue was modified concurrently")
+
+// ErrIssueNotFound is returned when an operation names an issue that doesn't exist
+var ErrIssueNotFound = errors.New("issue not found")
+
+// ErrCycleDetected is returned by AddDependency when a blocks dependency would make
+// an issue (transitively) block itself, and by SetParent when an issue would become
+// its own ancestor
+var ErrCycleDetected = errors.New("dependency cycle detected")
+
+// ErrAliasInUse is returned by AddIDAlias when the alias already names an issue,
+// either as another alias or as an issue ID
+var ErrAliasInUse = errors.New("alias already in use")
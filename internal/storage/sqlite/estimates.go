@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// GetEstimateRollup sums the estimates of all of an issue's subtasks, walking the
+// parent-child dependencies down the full subtree so epic totals include nested work.
+// Each descendant is counted once, even if reachable through several parents,
+// and cycles terminate. The parent's own estimate is not included.
+func (s *SQLiteStorage) GetEstimateRollup(ctx context.Context, parentID string) (*types.EstimateRollup, error) {
+	rollup := &types.EstimateRollup{IssueID: parentID}
+
+	// UNION (not UNION ALL) discards already-visited issues, which
+	// both deduplicates shared subtasks and stops on cycles
+	err := s.db.QueryRowContext(ctx, `
+		WITH RECURSIVE subtree(id) AS (
+			SELECT d.issue_id
+			FROM dependencies d
+			WHERE d.depends_on_id = ? AND d.type = ?
+
+			UNION
+
+			SELECT d.issue_id
+			FROM dependencies d
+			JOIN subtree st ON d.depends_on_id = st.id
+			WHERE d.type = ?
+		)
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN i.estimated_minutes IS NULL
+			                   AND i.estimate_min_minutes IS NULL
+			                   AND i.estimate_max_minutes IS NULL THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(i.estimated_minutes), 0),
+			COALESCE(SUM(COALESCE(i.estimate_min_minutes, i.estimated_minutes)), 0),
+			COALESCE(SUM(COALESCE(i.estimate_max_minutes, i.estimated_minutes)), 0)
+		FROM issues i
+		JOIN subtree st ON st.id = i.id
+		WHERE i.id != ?
+	`, parentID, types.DepParentChild, types.DepParentChild, parentID).Scan(
+		&rollup.Subtasks, &rollup.Unestimated,
+		&rollup.EstimatedMinutes, &rollup.MinMinutes, &rollup.MaxMinutes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute estimate rollup: %w", err)
+	}
+
+	return rollup, nil
+}
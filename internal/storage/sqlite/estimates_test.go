@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestEstimateRangeValidation(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	bad := &types.Issue{
+		Title: "Inverted range", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		EstimatedMinutes: intPtr(30), EstimateMinMinutes: intPtr(60),
+	}
+	if err := store.CreateIssue(ctx, bad, "test"); err == nil {
+		t.Error("Expected CreateIssue to reject point estimate below min")
+	}
+
+	issue := &types.Issue{
+		Title: "Ranged", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		EstimatedMinutes: intPtr(30), EstimateMinMinutes: intPtr(20), EstimateMaxMinutes: intPtr(60),
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.EstimateMinMinutes == nil || *got.EstimateMinMinutes != 20 ||
+		got.EstimateMaxMinutes == nil || *got.EstimateMaxMinutes != 60 {
+		t.Errorf("Expected range 20-60, got %v-%v", got.EstimateMinMinutes, got.EstimateMaxMinutes)
+	}
+
+	// A partial update is validated against the stored values
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"estimated_minutes": 90}, "test"); err == nil {
+		t.Error("Expected UpdateIssue to reject point estimate above stored max")
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"estimate_min_minutes": 45}, "test"); err == nil {
+		t.Error("Expected UpdateIssue to reject min above stored point estimate")
+	}
+
+	// Widening the range together with the point estimate is fine
+	err = store.UpdateIssue(ctx, issue.ID, map[string]interface{}{
+		"estimated_minutes":    90,
+		"estimate_max_minutes": 120,
+	}, "test")
+	if err != nil {
+		t.Fatalf("Expected consistent update to succeed, got %v", err)
+	}
+
+	// Clearing a bound is allowed
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"estimate_max_minutes": nil}, "test"); err != nil {
+		t.Fatalf("Expected clearing max to succeed, got %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.EstimateMaxMinutes != nil {
+		t.Errorf("Expected max to be cleared, got %d", *got.EstimateMaxMinutes)
+	}
+}
+
+func TestGetEstimateRollup(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	parent := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "test"); err != nil {
+		t.Fatalf("Failed to create parent: %v", err)
+	}
+
+	children := []*types.Issue{
+		{Title: "Ranged", EstimatedMinutes: intPtr(30), EstimateMinMinutes: intPtr(20), EstimateMaxMinutes: intPtr(60)},
+		{Title: "Point only", EstimatedMinutes: intPtr(10)},
+		{Title: "Unestimated"},
+	}
+	for _, child := range children {
+		child.Status, child.Priority, child.IssueType = types.StatusOpen, 2, types.TypeTask
+		if err := store.CreateIssue(ctx, child, "test"); err != nil {
+			t.Fatalf("Failed to create child: %v", err)
+		}
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("Failed to link child: %v", err)
+		}
+	}
+
+	rollup, err := store.GetEstimateRollup(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("GetEstimateRollup failed: %v", err)
+	}
+
+	want := types.EstimateRollup{
+		IssueID:          parent.ID,
+		Subtasks:         3,
+		Unestimated:      1,
+		EstimatedMinutes: 40,
+		MinMinutes:       30,
+		MaxMinutes:       70,
+	}
+	if *rollup != want {
+		t.Errorf("Unexpected rollup:\n got  %+v\n want %+v", *rollup, want)
+	}
+}
+
+func TestGetEstimateRollupRecursive(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	create := func(title string, estimate *int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: estimate}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", title, err)
+		}
+		return issue
+	}
+	link := func(child, parent *types.Issue) {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("Failed to link %s to %s: %v", child.ID, parent.ID, err)
+		}
+	}
+
+	// epic -> {feature A -> {leaf 1, leaf 2}, feature B -> {leaf 3}}
+	epic := create("Epic", intPtr(999))
+	featureA := create("Feature A", nil)
+	featureB := create("Feature B", nil)
+	leaf1 := create("Leaf 1", intPtr(10))
+	leaf2 := create("Leaf 2", intPtr(20))
+	leaf3 := create("Leaf 3", intPtr(40))
+	link(featureA, epic)
+	link(featureB, epic)
+	link(leaf1, featureA)
+	link(leaf2, featureA)
+	link(leaf3, featureB)
+
+	rollup, err := store.GetEstimateRollup(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEstimateRollup failed: %v", err)
+	}
+	if rollup.EstimatedMinutes != 70 {
+		t.Errorf("Expected 70 minutes across all leaves, got %d", rollup.EstimatedMinutes)
+	}
+	if rollup.Subtasks != 5 || rollup.Unestimated != 2 {
+		t.Errorf("Expected 5 subtasks with 2 unestimated, got %+v", *rollup)
+	}
+
+	// A subtree rooted lower down only counts its own descendants
+	rollup, err = store.GetEstimateRollup(ctx, featureA.ID)
+	if err != nil {
+		t.Fatalf("GetEstimateRollup failed: %v", err)
+	}
+	if rollup.EstimatedMinutes != 30 || rollup.Subtasks != 2 {
+		t.Errorf("Expected feature A to roll up 2 subtasks and 30 minutes, got %+v", *rollup)
+	}
+}
+
+func TestGetEstimateRollupCycle(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"A", "B", "C"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: intPtr(5)}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	// Parent-child links are not cycle-checked, so a bad import can produce A <- B <- C <- A
+	for i := range issues {
+		child, parent := issues[(i+1)%len(issues)], issues[i]
+		_, err := store.db.Exec(`
+			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by)
+			VALUES (?, ?, ?, 'test')
+		`, child.ID, parent.ID, types.DepParentChild)
+		if err != nil {
+			t.Fatalf("Failed to insert dependency: %v", err)
+		}
+	}
+
+	rollup, err := store.GetEstimateRollup(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("GetEstimateRollup failed: %v", err)
+	}
+	if rollup.Subtasks != 2 || rollup.EstimatedMinutes != 10 {
+		t.Errorf("Expected cycle to roll up B and C once each, got %+v", *rollup)
+	}
+}
@@ -0,0 +1,30 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// GetIssueETag returns a cache validator for an issue: a hash of every stored field,
+// so it changes whenever the issue does (even twice within updated_at's one second
+// resolution) and is stable across reads. The HTTP layer should quote it to form
+// the ETag header value.
+func (s *SQLiteStorage) GetIssueETag(ctx context.Context, id string) (string, error) {
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if issue == nil {
+		return "", fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal issue: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
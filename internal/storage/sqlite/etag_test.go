@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestGetIssueETag(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Cached", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	first, err := store.GetIssueETag(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueETag failed: %v", err)
+	}
+	again, err := store.GetIssueETag(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueETag failed: %v", err)
+	}
+	if first == "" || first != again {
+		t.Errorf("Expected a stable ETag across reads, got %q and %q", first, again)
+	}
+
+	// Changes within the same second still change the ETag
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	updated, err := store.GetIssueETag(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueETag failed: %v", err)
+	}
+	if updated == first {
+		t.Error("Expected the ETag to change after an update")
+	}
+
+	if _, err := store.GetIssueETag(ctx, "vc-9999"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
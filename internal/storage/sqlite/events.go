@@ -3,30 +3,36 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/steveyegge/vc/internal/types"
 )
 
-// AddComment adds a comment to an issue
-func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, issueID, types.EventCommented, actor, comment)
+// BackfillClosedAt sets closed_at on closed issues that lack it, such as issues closed
+// through UpdateIssue rather than CloseIssue. The time comes from the issue's most
+// recent EventClosed, falling back to updated_at. Returns the number of issues fixed.
+func (s *SQLiteStorage) BackfillClosedAt(ctx context.Context) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE issues SET closed_at = COALESCE(
+			(SELECT e.created_at FROM events e
+			 WHERE e.issue_id = issues.id AND e.event_type = ?
+			 ORDER BY e.created_at DESC, e.id DESC
+			 LIMIT 1),
+			updated_at
+		)
+		WHERE status = ? AND closed_at IS NULL AND (? = '' OR project_id = ?)
+	`, types.EventClosed, types.StatusClosed, s.opts.project, s.opts.project)
 	if err != nil {
-		return fmt.Errorf("failed to add comment: %w", err)
+		return 0, fmt.Errorf("failed to backfill closed_at: %w", err)
 	}
 
-	// Update issue updated_at timestamp
-	_, err = s.db.ExecContext(ctx, `
-		UPDATE issues SET updated_at = CURRENT_TIMESTAMP WHERE id = ?
-	`, issueID)
+	n, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to update timestamp: %w", err)
+		return 0, fmt.Errorf("failed to check rows affected: %w", err)
 	}
-
-	return nil
+	return int(n), nil
 }
 
 // GetEvents returns the event history for an issue
@@ -40,16 +46,92 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
 		FROM events
 		WHERE issue_id = ?
+		  AND (? = '' OR issue_id IN (SELECT id FROM issues WHERE project_id = ?))
 		ORDER BY created_at DESC
 		%s
 	`, limitSQL)
 
-	rows, err := s.db.QueryContext(ctx, query, issueID)
+	rows, err := s.db.QueryContext(ctx, query, issueID, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueEvents(rows)
+}
+
+// GetEventTimeline returns an issue's full event history, oldest first, for timeline
+// views. Old and new values are the raw JSON written by the storage layer. Unlike
+// GetEvents, which returns the newest events first, an issue without events yields
+// an empty, non-nil slice.
+func (s *SQLiteStorage) GetEventTimeline(ctx context.Context, issueID string) ([]*types.Event, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
+	events, err := scanIssueEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []*types.Event{}
+	}
+	return events, nil
+}
+
+// RecordEvent appends an event to an issue's history, for integrations that change
+// issues outside this package's methods and want the timeline to say so. Old and
+// new values are stored as JSON, like those written by UpdateIssue; nil values and
+// an empty comment are stored as NULL. eventType may be one of the types.Event*
+// constants or an integration's own type. Returns ErrIssueNotFound if the issue
+// doesn't exist.
+func (s *SQLiteStorage) RecordEvent(ctx context.Context, issueID string, eventType types.EventType, actor, comment string, oldValue, newValue interface{}) error {
+	if strings.TrimSpace(string(eventType)) == "" {
+		return types.NewValidationError("event_type", eventType, types.ErrInvalidField, "event type is required")
+	}
+	if strings.TrimSpace(actor) == "" {
+		return types.NewValidationError("actor", actor, types.ErrInvalidField, "actor is required")
+	}
+	found, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	var values [2]sql.NullString
+	for i, v := range []interface{}{oldValue, newValue} {
+		if v == nil {
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		values[i] = sql.NullString{String: string(data), Valid: true}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, issueID, eventType, actor, values[0], values[1], sql.NullString{String: comment, Valid: comment != ""}, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
+}
+
+// scanIssueEvents scans rows of id, issue_id, event_type, actor, old_value, new_value,
+// comment, created_at
+func scanIssueEvents(rows *sql.Rows) ([]*types.Event, error) {
 	var events []*types.Event
 	for rows.Next() {
 		var event types.Event
@@ -75,10 +157,67 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 
 		events = append(events, &event)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
 
 	return events, nil
 }
 
+// BackfillCreationEvents synthesizes an EventCreated for every issue that lacks one,
+// such as issues from databases that predate event logging or raw imports.
+// Each synthesized event snapshots the current row and is timestamped with the
+// issue's created_at. Returns the number of events created.
+func (s *SQLiteStorage) BackfillCreationEvents(ctx context.Context, actor string) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE NOT EXISTS (
+			SELECT 1 FROM events e
+			WHERE e.issue_id = i.id AND e.event_type = ?
+		)
+		ORDER BY i.created_at ASC
+	`, types.EventCreated)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find issues without creation events: %w", err)
+	}
+	issues, err := s.scanIssues(rows)
+	_ = rows.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, issue := range issues {
+		stored, err := s.cipher.encryptIssue(issue)
+		if err != nil {
+			return 0, err
+		}
+		eventData, err := json.Marshal(stored)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal issue %s: %w", issue.ID, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, issue.ID, types.EventCreated, actor, string(eventData),
+			"Backfilled creation event", issue.CreatedAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record creation event for %s: %w", issue.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit backfill: %w", err)
+	}
+	return len(issues), nil
+}
+
 // GetStatistics returns aggregate statistics
 func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	var stats types.Statistics
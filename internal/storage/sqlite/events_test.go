@@ -0,0 +1,226 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestBackfillCreationEvents(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	// An issue created normally already has its creation event
+	normal := &types.Issue{Title: "Normal", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, normal, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	// A raw insert has no events at all
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	_, err := store.db.Exec(`
+		INSERT INTO issues (id, title, status, priority, issue_type, created_at, updated_at)
+		VALUES ('vc-500', 'Legacy issue', 'open', 1, 'bug', ?, ?)
+	`, createdAt, createdAt)
+	if err != nil {
+		t.Fatalf("Failed to insert legacy issue: %v", err)
+	}
+
+	count, err := store.BackfillCreationEvents(ctx, "backfill")
+	if err != nil {
+		t.Fatalf("BackfillCreationEvents failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 backfilled event, got %d", count)
+	}
+
+	events, err := store.GetEvents(ctx, "vc-500", 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event for legacy issue, got %d", len(events))
+	}
+	event := events[0]
+	if event.EventType != types.EventCreated || event.Actor != "backfill" {
+		t.Errorf("Unexpected event: %s by %s", event.EventType, event.Actor)
+	}
+	if !event.CreatedAt.Equal(createdAt) {
+		t.Errorf("Expected event at %v, got %v", createdAt, event.CreatedAt)
+	}
+	if event.NewValue == nil || !contains(*event.NewValue, "Legacy issue") {
+		t.Errorf("Expected event to snapshot the issue row, got %v", event.NewValue)
+	}
+
+	// Running again is a no-op
+	count, err = store.BackfillCreationEvents(ctx, "backfill")
+	if err != nil {
+		t.Fatalf("Second BackfillCreationEvents failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no events on second run, got %d", count)
+	}
+}
+
+func TestGetEventTimeline(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Timeline", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "creator"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	setEventTimes(t, store, issue.ID, base, base.Add(time.Hour), base.Add(2*time.Hour))
+
+	events, err := store.GetEventTimeline(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetEventTimeline failed: %v", err)
+	}
+	want := []types.EventType{types.EventCreated, types.EventUpdated, types.EventClosed}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		if event.EventType != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], event.EventType)
+		}
+	}
+	if events[1].Actor != "alice" || events[1].NewValue == nil || *events[1].NewValue != `{"priority":1}` {
+		t.Errorf("Expected alice's update with its raw new value, got %+v", events[1])
+	}
+	if events[2].Comment == nil || *events[2].Comment != "Done" {
+		t.Errorf("Expected the close reason as comment, got %v", events[2].Comment)
+	}
+
+	empty, err := store.GetEventTimeline(ctx, "vc-9999")
+	if err != nil {
+		t.Fatalf("GetEventTimeline failed: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Expected an empty slice for an issue without events, got %v", empty)
+	}
+}
+
+func TestBackfillClosedAt(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	// Closing through UpdateIssue records an EventClosed but leaves closed_at unset
+	viaUpdate := &types.Issue{Title: "Closed by update", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, viaUpdate, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, viaUpdate.ID, map[string]interface{}{"status": string(types.StatusClosed)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	created := time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)
+	closed := created.Add(48 * time.Hour)
+	setEventTimes(t, store, viaUpdate.ID, created, closed)
+
+	// A raw row without any events falls back to updated_at
+	updatedAt := time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC)
+	_, err := store.db.Exec(`
+		INSERT INTO issues (id, title, status, priority, issue_type, created_at, updated_at)
+		VALUES ('vc-500', 'Legacy closed issue', 'closed', 1, 'bug', ?, ?)
+	`, updatedAt.Add(-time.Hour), updatedAt)
+	if err != nil {
+		t.Fatalf("Failed to insert legacy issue: %v", err)
+	}
+
+	// Issues closed normally are left alone
+	normal := &types.Issue{Title: "Closed normally", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, normal, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, normal.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	fixed, err := store.BackfillClosedAt(ctx)
+	if err != nil {
+		t.Fatalf("BackfillClosedAt failed: %v", err)
+	}
+	if fixed != 2 {
+		t.Errorf("Expected 2 issues fixed, got %d", fixed)
+	}
+
+	for id, want := range map[string]time.Time{viaUpdate.ID: closed, "vc-500": updatedAt} {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.ClosedAt == nil || !issue.ClosedAt.Equal(want) {
+			t.Errorf("Expected %s closed_at %v, got %v", id, want, issue.ClosedAt)
+		}
+	}
+
+	// Nothing is left to fix
+	fixed, err = store.BackfillClosedAt(ctx)
+	if err != nil {
+		t.Fatalf("BackfillClosedAt failed: %v", err)
+	}
+	if fixed != 0 {
+		t.Errorf("Expected a second run to fix nothing, got %d", fixed)
+	}
+}
+
+func TestRecordEvent(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Synced from CI", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	const deployed types.EventType = "deployed"
+	err := store.RecordEvent(ctx, issue.ID, deployed, "ci-bot", "Deployed to staging",
+		map[string]string{"env": "dev"}, map[string]string{"env": "staging"})
+	if err != nil {
+		t.Fatalf("RecordEvent failed: %v", err)
+	}
+	if err := store.RecordEvent(ctx, issue.ID, types.EventCommented, "ci-bot", "Build green", nil, nil); err != nil {
+		t.Fatalf("RecordEvent failed: %v", err)
+	}
+
+	events, err := store.GetEventTimeline(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetEventTimeline failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	e := events[1]
+	if e.EventType != deployed || e.Actor != "ci-bot" || e.Comment == nil || *e.Comment != "Deployed to staging" {
+		t.Errorf("Unexpected custom event %+v", e)
+	}
+	if e.OldValue == nil || *e.OldValue != `{"env":"dev"}` || e.NewValue == nil || *e.NewValue != `{"env":"staging"}` {
+		t.Errorf("Expected JSON old and new values, got %v and %v", e.OldValue, e.NewValue)
+	}
+	if e := events[2]; e.OldValue != nil || e.NewValue != nil {
+		t.Errorf("Expected nil values to be stored as NULL, got %+v", e)
+	}
+
+	if err := store.RecordEvent(ctx, "vc-999", deployed, "ci-bot", "", nil, nil); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+	if err := store.RecordEvent(ctx, issue.ID, "", "ci-bot", "", nil, nil); err == nil {
+		t.Error("Expected an empty event type to be rejected")
+	}
+	if err := store.RecordEvent(ctx, issue.ID, deployed, " ", "", nil, nil); err == nil {
+		t.Error("Expected an empty actor to be rejected")
+	}
+	if err := store.RecordEvent(ctx, issue.ID, deployed, "ci-bot", "", make(chan int), nil); err == nil {
+		t.Error("Expected an unmarshalable value to be rejected")
+	}
+}
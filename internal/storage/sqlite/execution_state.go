@@ -4,10 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/mattn/go-sqlite3"
 	"github.com/steveyegge/vc/internal/types"
 )
 
@@ -44,6 +45,15 @@ func (s *SQLiteStorage) ClaimIssue(ctx context.Context, issueID, executorInstanc
 		return fmt.Errorf("issue %s is not open (status: %s)", issueID, issueStatus)
 	}
 
+	// Claiming moves the issue to in_progress, which counts against its assignee's WIP limit
+	var assignee sql.NullString
+	if err := tx.QueryRowContext(ctx, "SELECT assignee FROM issues WHERE id = ?", issueID).Scan(&assignee); err != nil {
+		return fmt.Errorf("failed to check issue assignee: %w", err)
+	}
+	if err := s.checkWIPLimit(ctx, tx, issueID, assignee.String); err != nil {
+		return err
+	}
+
 	// Verify executor instance exists
 	var instanceExists bool
 	err = tx.QueryRowContext(ctx, "SELECT 1 FROM executor_instances WHERE instance_id = ?", executorInstanceID).Scan(&instanceExists)
@@ -80,9 +90,9 @@ func (s *SQLiteStorage) ClaimIssue(ctx context.Context, issueID, executorInstanc
 
 	// Record event
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, issueID, types.EventStatusChanged, executorInstanceID, "Issue claimed by executor")
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, issueID, types.EventStatusChanged, executorInstanceID, "Issue claimed by executor", now)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -279,7 +289,7 @@ func (s *SQLiteStorage) ReleaseIssueAndReopen(ctx context.Context, issueID, acto
 	// This handles cases where the state might have been cleaned up already
 	if rows == 0 {
 		// Just log a warning, don't fail - we still want to reset status and add comment
-		fmt.Fprintf(os.Stderr, "warning: no execution state found for issue %s during release\n", issueID)
+		s.opts.warnf("no execution state found for issue %s during release", issueID)
 	}
 
 	// Update issue status back to open
@@ -368,9 +378,12 @@ func isValidStateTransition(from, to types.ExecutionState) bool {
 	return false
 }
 
-// isUniqueConstraintError checks if an error is a unique constraint violation
+// isUniqueConstraintError checks if an error is a unique or primary key constraint violation
 func isUniqueConstraintError(err error) bool {
-	// SQLite returns "UNIQUE constraint failed" in the error message
-	return err != nil && (err.Error() == "UNIQUE constraint failed: issue_execution_state.issue_id" ||
-		err.Error() == "PRIMARY KEY must be unique")
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
 }
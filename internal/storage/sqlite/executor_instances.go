@@ -283,9 +283,9 @@ func (s *SQLiteStorage) CleanupStaleInstances(ctx context.Context, staleThreshol
 				comment = fmt.Sprintf("Issue automatically released - executor instance %s was already stopped but claim remained (orphaned)", instanceID)
 			}
 			_, err = tx.ExecContext(ctx, `
-				INSERT INTO events (issue_id, event_type, actor, comment)
-				VALUES (?, ?, ?, ?)
-			`, issueID, types.EventStatusChanged, "system", comment)
+				INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+				VALUES (?, ?, ?, ?, ?)
+			`, issueID, types.EventStatusChanged, "system", comment, s.now())
 			if err != nil {
 				return 0, fmt.Errorf("failed to add release comment for issue %s: %w", issueID, err)
 			}
@@ -986,7 +986,7 @@ func TestDeleteOldStoppedInstances(t *testing.T) {
 }
 
 // setupTestDB creates a temporary test database
-func setupTestDB(t *testing.T) *SQLiteStorage {
+func setupTestDB(t *testing.T, opts ...Option) *SQLiteStorage {
 	t.Helper()
 
 	// Create temp file
@@ -997,7 +997,7 @@ func setupTestDB(t *testing.T) *SQLiteStorage {
 	_ = tmpfile.Close()
 
 	// Create storage
-	storage, err := New(tmpfile.Name())
+	storage, err := New(tmpfile.Name(), opts...)
 	if err != nil {
 		t.Fatalf("Failed to create storage: %v", err)
 	}
@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// ExportNDJSON writes the issues matching filter to w as newline-delimited JSON, one
+// complete issue object per line, for piping into jq or a log processor. Issues are
+// streamed with IterateIssues, so memory use doesn't grow with the result set.
+func (s *SQLiteStorage) ExportNDJSON(ctx context.Context, w io.Writer, filter types.IssueFilter) error {
+	enc := json.NewEncoder(w)
+	return s.IterateIssues(ctx, "", filter, func(issue *types.Issue) error {
+		if err := enc.Encode(issue); err != nil {
+			return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+		}
+		return nil
+	})
+}
+
+// ImportJSON recreates the issues in r, a stream of issue objects as written by
+// ExportNDJSON, in a single transaction, and returns how many were imported. IDs and
+// timestamps are preserved when present: issues without an ID get the next one, and
+// missing timestamps default to now. An ID that already exists fails the import.
+// Each imported issue gets a comment event by actor noting the import.
+func (s *SQLiteStorage) ImportJSON(ctx context.Context, r io.Reader, actor string) (imported int, err error) {
+	return s.ImportJSONWithOptions(ctx, r, types.ImportOptions{}, actor)
+}
+
+// ImportJSONWithOptions is ImportJSON adjusted by opts: OnConflict chooses whether
+// existing IDs fail the import, are skipped, or are overwritten, and SkipImportEvents
+// leaves out the per-issue import events. Nothing is written unless every issue
+// decodes, validates, and imports.
+func (s *SQLiteStorage) ImportJSONWithOptions(ctx context.Context, r io.Reader, opts types.ImportOptions, actor string) (imported int, err error) {
+	switch opts.OnConflict {
+	case types.ConflictError, types.ConflictSkip, types.ConflictOverwrite:
+	default:
+		return 0, fmt.Errorf("invalid conflict policy: %s", opts.OnConflict)
+	}
+
+	// Decode and validate everything before writing
+	var issues []*types.Issue
+	dec := json.NewDecoder(r)
+	for {
+		var issue types.Issue
+		if err := dec.Decode(&issue); err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("failed to read issue %d: %w", len(issues)+1, err)
+		}
+		if err := issue.ValidateWithMaxPriority(s.maxPriority); err != nil {
+			return 0, fmt.Errorf("invalid issue %d (%s): %w", len(issues)+1, issue.ID, err)
+		}
+		issues = append(issues, &issue)
+	}
+
+	// BEGIN IMMEDIATE, as in createIssue, since issues without IDs need new ones
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := s.beginImmediate(ctx, conn); err != nil {
+		return 0, fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	now := s.now()
+	for _, issue := range issues {
+		if s.opts.project != "" {
+			issue.ProjectID = s.opts.project
+		}
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = now
+		}
+		if issue.UpdatedAt.IsZero() {
+			issue.UpdatedAt = issue.CreatedAt
+		}
+
+		if err := s.importIssue(ctx, conn, issue, opts.OnConflict, actor); err == errSkipImport {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+		imported++
+
+		if !opts.SkipImportEvents {
+			_, err := conn.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+				VALUES (?, ?, ?, ?, ?)
+			`, issue.ID, types.EventCommented, actor, "Imported from JSON", now)
+			if err != nil {
+				return 0, fmt.Errorf("failed to record event: %w", err)
+			}
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return imported, nil
+}
+
+// errSkipImport tells ImportJSONWithOptions an issue was skipped under ConflictSkip
+var errSkipImport = errors.New("skip import")
+
+// importIssue writes one issue for ImportJSONWithOptions on conn, inside its
+// transaction. Issues without an ID are created like new ones, with a creation event.
+func (s *SQLiteStorage) importIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue, onConflict types.ConflictPolicy, actor string) error {
+	if issue.ID == "" {
+		return s.insertIssue(ctx, conn, issue, actor)
+	}
+
+	var existingProject string
+	err := conn.QueryRowContext(ctx, `SELECT project_id FROM issues WHERE id = ?`, issue.ID).Scan(&existingProject)
+	exists := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check issue %s: %w", issue.ID, err)
+	}
+	if exists {
+		switch {
+		case onConflict == types.ConflictSkip:
+			return errSkipImport
+		case onConflict == types.ConflictError:
+			return fmt.Errorf("issue %s already exists", issue.ID)
+		case existingProject != issue.ProjectID:
+			return fmt.Errorf("issue %s already exists in another project", issue.ID)
+		}
+	}
+	return s.writeImportedIssue(ctx, conn, issue, exists)
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestExportNDJSON(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	for _, typ := range []types.IssueType{types.TypeBug, types.TypeBug, types.TypeTask} {
+		issue := &types.Issue{Title: "Exported", Status: types.StatusOpen, Priority: 2, IssueType: typ}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	bug := types.TypeBug
+	var buf bytes.Buffer
+	if err := store.ExportNDJSON(ctx, &buf, types.IssueFilter{IssueType: &bug}); err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+
+	lines := 0
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		lines++
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", lines, err)
+		}
+		if issue.ID == "" || issue.IssueType != types.TypeBug {
+			t.Errorf("Line %d: expected a complete bug issue, got %+v", lines, issue)
+		}
+	}
+	if lines != 2 {
+		t.Errorf("Expected 2 lines, one per matching issue, got %d", lines)
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	src := setupTestDB(t)
+	ctx := context.Background()
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, title := range []string{"First", "Second"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+		if err := src.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if _, err := src.db.Exec(`UPDATE issues SET created_at = ?, updated_at = ?`, created, created); err != nil {
+		t.Fatalf("Failed to backdate issues: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := src.ExportNDJSON(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("ExportNDJSON failed: %v", err)
+	}
+	export := buf.String()
+
+	dst := setupTestDB(t)
+	imported, err := dst.ImportJSON(ctx, strings.NewReader(export), "importer")
+	if err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if imported != 2 {
+		t.Errorf("Expected 2 imported issues, got %d", imported)
+	}
+	want, _ := src.SearchIssues(ctx, "", types.IssueFilter{})
+	for _, w := range want {
+		got, err := dst.GetIssue(ctx, w.ID)
+		if err != nil || got == nil {
+			t.Fatalf("Expected %s to be imported, got %v", w.ID, err)
+		}
+		if got.Title != w.Title || !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(created) {
+			t.Errorf("Expected %s to keep its title and timestamps, got %+v", w.ID, got)
+		}
+	}
+
+	// Existing IDs fail by default, leaving nothing behind
+	extra := `{"title":"New","status":"open","priority":2,"issue_type":"task"}` + "\n"
+	if _, err := dst.ImportJSON(ctx, strings.NewReader(extra+export), "importer"); err == nil {
+		t.Error("Expected an error for existing IDs")
+	}
+	if count, _ := dst.CountIssues(ctx, "", types.IssueFilter{}); count != 2 {
+		t.Errorf("Expected the failed import to roll back, got %d issues", count)
+	}
+
+	// A malformed record near the end rolls back the whole import
+	if _, err := dst.ImportJSON(ctx, strings.NewReader(extra+`{"title":`), "importer"); err == nil {
+		t.Error("Expected an error for a malformed record")
+	}
+	if count, _ := dst.CountIssues(ctx, "", types.IssueFilter{}); count != 2 {
+		t.Errorf("Expected the malformed import to write nothing, got %d issues", count)
+	}
+
+	// Skip keeps existing issues; issues without IDs get new ones
+	imported, err = dst.ImportJSONWithOptions(ctx, strings.NewReader(extra+export), types.ImportOptions{OnConflict: types.ConflictSkip}, "importer")
+	if err != nil {
+		t.Fatalf("ImportJSONWithOptions(skip) failed: %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("Expected only the new issue to be imported, got %d", imported)
+	}
+
+	// Overwrite replaces fields in place
+	edited := strings.Replace(export, `"title":"First"`, `"title":"First, edited"`, 1)
+	imported, err = dst.ImportJSONWithOptions(ctx, strings.NewReader(edited), types.ImportOptions{OnConflict: types.ConflictOverwrite}, "importer")
+	if err != nil {
+		t.Fatalf("ImportJSONWithOptions(overwrite) failed: %v", err)
+	}
+	if imported != 2 {
+		t.Errorf("Expected 2 overwritten issues, got %d", imported)
+	}
+	results, _ := dst.SearchIssues(ctx, "edited", types.IssueFilter{})
+	if len(results) != 1 {
+		t.Errorf("Expected the overwritten title to be searchable, got %v", results)
+	}
+	if count, _ := dst.CountIssues(ctx, "", types.IssueFilter{}); count != 3 {
+		t.Errorf("Expected 3 issues after overwrite, got %d", count)
+	}
+}
@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// FlagIssue flags an issue for moderation or triage and returns the new flag's ID.
+// The code must be one of the allowed types.FlagCode values. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) FlagIssue(ctx context.Context, issueID string, code types.FlagCode, note, flagger string) (int64, error) {
+	if !code.IsValid() {
+		return 0, fmt.Errorf("invalid flag code: %s", code)
+	}
+	if strings.TrimSpace(flagger) == "" {
+		return 0, types.NewValidationError("flagger", flagger, types.ErrInvalidField, "flagger is required")
+	}
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO flags (issue_id, code, note, flagger)
+		VALUES (?, ?, ?, ?)
+	`, issueID, code, note, flagger)
+	if err != nil {
+		return 0, fmt.Errorf("failed to flag issue %s: %w", issueID, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get flag ID: %w", err)
+	}
+	return id, nil
+}
+
+// ResolveFlag marks a flag as resolved. Resolved flags remain in the history
+// returned by GetFlags but no longer match the HasFlag filter.
+func (s *SQLiteStorage) ResolveFlag(ctx context.Context, flagID int64, actor string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE flags SET resolved_at = ?, resolved_by = ?
+		WHERE id = ? AND resolved_at IS NULL
+	`, s.now(), actor, flagID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve flag: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("flag %d not found or already resolved", flagID)
+	}
+	return nil
+}
+
+// GetFlags returns all flags on an issue, resolved or not, oldest first
+func (s *SQLiteStorage) GetFlags(ctx context.Context, issueID string) ([]*types.Flag, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, code, note, flagger, created_at, resolved_at, resolved_by
+		FROM flags
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var flags []*types.Flag
+	for rows.Next() {
+		var flag types.Flag
+		var resolvedAt sql.NullTime
+		var resolvedBy sql.NullString
+		err := rows.Scan(
+			&flag.ID, &flag.IssueID, &flag.Code, &flag.Note, &flag.Flagger,
+			&flag.CreatedAt, &resolvedAt, &resolvedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan flag: %w", err)
+		}
+		if resolvedAt.Valid {
+			flag.ResolvedAt = &resolvedAt.Time
+		}
+		if resolvedBy.Valid {
+			flag.ResolvedBy = resolvedBy.String
+		}
+		flags = append(flags, &flag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating flags: %w", err)
+	}
+
+	return flags, nil
+}
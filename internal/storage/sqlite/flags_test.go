@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestFlagIssue(t *testing.T) {
+	now := time.Date(2025, 6, 2, 10, 30, 0, 0, time.UTC)
+	store := setupTestDB(t, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Suspicious", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	if _, err := store.FlagIssue(ctx, issue.ID, "rude", "", "mod"); err == nil {
+		t.Error("Expected error for code outside the allowlist")
+	}
+
+	spamID, err := store.FlagIssue(ctx, issue.ID, types.FlagSpam, "looks like an ad", "mod")
+	if err != nil {
+		t.Fatalf("FlagIssue failed: %v", err)
+	}
+	if _, err := store.FlagIssue(ctx, issue.ID, types.FlagSecurity, "", "mod"); err != nil {
+		t.Fatalf("FlagIssue failed: %v", err)
+	}
+
+	flags, err := store.GetFlags(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetFlags failed: %v", err)
+	}
+	if len(flags) != 2 {
+		t.Fatalf("Expected 2 flags, got %d", len(flags))
+	}
+	if flags[0].Code != types.FlagSpam || flags[0].Note != "looks like an ad" || flags[0].Flagger != "mod" {
+		t.Errorf("Unexpected first flag: %+v", flags[0])
+	}
+
+	if err := store.ResolveFlag(ctx, spamID, "admin"); err != nil {
+		t.Fatalf("ResolveFlag failed: %v", err)
+	}
+	if err := store.ResolveFlag(ctx, spamID, "admin"); err == nil {
+		t.Error("Expected error resolving an already-resolved flag")
+	}
+
+	flags, _ = store.GetFlags(ctx, issue.ID)
+	if flags[0].ResolvedAt == nil || flags[0].ResolvedBy != "admin" {
+		t.Errorf("Expected spam flag to be resolved by admin, got %+v", flags[0])
+	} else if !flags[0].ResolvedAt.Equal(now) {
+		t.Errorf("Expected spam flag to be resolved at %v, got %v", now, flags[0].ResolvedAt)
+	}
+}
+
+func TestSearchIssuesHasFlag(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"Security hole", "Spam", "Clean"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if _, err := store.FlagIssue(ctx, ids[0], types.FlagSecurity, "", "mod"); err != nil {
+		t.Fatalf("FlagIssue failed: %v", err)
+	}
+	spamFlag, err := store.FlagIssue(ctx, ids[1], types.FlagSpam, "", "mod")
+	if err != nil {
+		t.Fatalf("FlagIssue failed: %v", err)
+	}
+
+	code := string(types.FlagSecurity)
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{HasFlag: &code})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != ids[0] {
+		t.Errorf("Expected only %s flagged security, got %d results", ids[0], len(results))
+	}
+
+	// Resolved flags no longer match
+	if err := store.ResolveFlag(ctx, spamFlag, "mod"); err != nil {
+		t.Fatalf("ResolveFlag failed: %v", err)
+	}
+	code = string(types.FlagSpam)
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{HasFlag: &code})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected resolved spam flag not to match, got %d results", len(results))
+	}
+
+	// Flags are removed with their issue
+	if _, err := store.db.Exec("DELETE FROM issues WHERE id = ?", ids[0]); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+	flags, err := store.GetFlags(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetFlags failed: %v", err)
+	}
+	if len(flags) != 0 {
+		t.Errorf("Expected flags to be cascade deleted, got %d", len(flags))
+	}
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// driverName is the database/sql driver New opens: the standard go-sqlite3 driver
+// with the helper SQL functions below registered on every connection.
+// connector wraps an equivalent driver when query timing or mirroring is enabled.
+const driverName = "sqlite3_vc"
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{ConnectHook: registerFunctions})
+}
+
+// priorityLabels names the default priority levels 0-4
+var priorityLabels = []string{"critical", "high", "medium", "low", "backlog"}
+
+// registerFunctions adds helper functions usable in raw SQL and ORDER BY expressions:
+//
+//	priority_label(n) - the name of priority level n (e.g. 0 -> "critical", 5 -> "p5")
+func registerFunctions(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc("priority_label", priorityLabel, true); err != nil {
+		return fmt.Errorf("failed to register priority_label: %w", err)
+	}
+	return nil
+}
+
+// priorityLabel returns the name of a priority level. Levels past the named ones,
+// allowed by WithMaxPriority, are labeled "p5", "p6" and so on. The function is
+// registered per connection and can't see the store's configured range, so any
+// level above 4 gets such a label; negative levels are "unknown".
+func priorityLabel(priority int64) string {
+	switch {
+	case priority < 0:
+		return "unknown"
+	case priority >= int64(len(priorityLabels)):
+		return fmt.Sprintf("p%d", priority)
+	}
+	return priorityLabels[priority]
+}
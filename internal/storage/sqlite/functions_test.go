@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestPriorityLabelFunction(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	for _, priority := range []int{0, 3} {
+		issue := &types.Issue{Title: "Labelled", Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	rows, err := store.db.QueryContext(ctx, `SELECT priority_label(priority) FROM issues ORDER BY priority_label(priority)`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		labels = append(labels, label)
+	}
+	if len(labels) != 2 || labels[0] != "critical" || labels[1] != "low" {
+		t.Errorf("Expected [critical low], got %v", labels)
+	}
+
+	var unknown string
+	if err := store.db.QueryRowContext(ctx, `SELECT priority_label(-1)`).Scan(&unknown); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if unknown != "unknown" {
+		t.Errorf("Expected negative priority to be unknown, got %q", unknown)
+	}
+}
+
+func TestPriorityLabelFunctionExtendedRange(t *testing.T) {
+	store := setupTestDB(t, WithMaxPriority(6))
+	ctx := context.Background()
+
+	for _, priority := range []int{4, 5, 6} {
+		issue := &types.Issue{Title: "Labelled", Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	rows, err := store.db.QueryContext(ctx, `SELECT priority_label(priority) FROM issues ORDER BY priority`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		labels = append(labels, label)
+	}
+	if len(labels) != 3 || labels[0] != "backlog" || labels[1] != "p5" || labels[2] != "p6" {
+		t.Errorf("Expected [backlog p5 p6], got %v", labels)
+	}
+}
@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// SetParent makes parentID the parent (epic) of childID, replacing any parent it had,
+// so the child keeps exactly one. An empty parentID detaches the child and makes it
+// top-level. There is intentionally no parent_id column: the hierarchy lives in the
+// parent-child dependencies AddDependency creates, so GetEstimateRollup,
+// GetDependents and child counts see the same structure, and a column would be a
+// second copy to keep in sync. An issue can't be its own parent (a ValidationError
+// on parent_id), and a parent can't be one of the child's descendants
+// (ErrCycleDetected). Returns ErrIssueNotFound if either issue doesn't exist.
+func (s *SQLiteStorage) SetParent(ctx context.Context, childID, parentID, actor string) error {
+	if childID == parentID {
+		return types.NewValidationError("parent_id", parentID, types.ErrInvalidField, "issue %s cannot be its own parent", childID)
+	}
+	for _, id := range []string{childID, parentID} {
+		if id == "" {
+			continue
+		}
+		exists, err := s.issueExists(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT depends_on_id FROM dependencies WHERE issue_id = ? AND type = ?
+	`, childID, types.DepParentChild)
+	if err != nil {
+		return fmt.Errorf("failed to get current parent: %w", err)
+	}
+	var oldParents []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan parent: %w", err)
+		}
+		oldParents = append(oldParents, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating parents: %w", err)
+	}
+	if len(oldParents) == 1 && oldParents[0] == parentID || len(oldParents) == 0 && parentID == "" {
+		return nil
+	}
+
+	if parentID != "" {
+		// The new parent must not already sit below the child. UNION stops on
+		// existing cycles in legacy data.
+		var cycle bool
+		err = tx.QueryRowContext(ctx, `
+			WITH RECURSIVE ancestors(id) AS (
+				SELECT ?
+				UNION
+				SELECT d.depends_on_id
+				FROM dependencies d
+				JOIN ancestors a ON d.issue_id = a.id
+				WHERE d.type = ?
+			)
+			SELECT EXISTS(SELECT 1 FROM ancestors WHERE id = ?)
+		`, parentID, types.DepParentChild, childID).Scan(&cycle)
+		if err != nil {
+			return fmt.Errorf("failed to check for cycles: %w", err)
+		}
+		if cycle {
+			return fmt.Errorf("cannot make %s the parent of its descendant %s: %w", childID, parentID, ErrCycleDetected)
+		}
+	}
+
+	now := s.now()
+	for _, oldParent := range oldParents {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+		`, childID, oldParent)
+		if err != nil {
+			return fmt.Errorf("failed to remove parent: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, childID, types.EventDependencyRemoved, actor, fmt.Sprintf("Removed dependency on %s", oldParent), now)
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+
+	if parentID != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+			VALUES (?, ?, ?, ?, ?)
+		`, childID, parentID, types.DepParentChild, now, actor)
+		if isUniqueConstraintError(err) {
+			return fmt.Errorf("%s already depends on %s with another dependency type", childID, parentID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set parent: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, childID, types.EventDependencyAdded, actor,
+			fmt.Sprintf("Added dependency: %s %s %s", childID, types.DepParentChild, parentID), now)
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetChildren returns the direct subtasks of an issue, highest priority first
+func (s *SQLiteStorage) GetChildren(ctx context.Context, parentID string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		WHERE d.depends_on_id = ? AND d.type = ?
+		  AND (? = '' OR i.project_id = ?)
+		ORDER BY i.priority ASC, i.created_at ASC
+	`, parentID, types.DepParentChild, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get children: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestSetParentAndGetChildren(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	create := func(title string, issueType types.IssueType, priority int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	epic := create("Epic", types.TypeEpic, 1)
+	other := create("Other epic", types.TypeEpic, 1)
+	low := create("Low subtask", types.TypeTask, 3)
+	high := create("High subtask", types.TypeTask, 0)
+	nested := create("Nested subtask", types.TypeTask, 2)
+
+	for _, link := range [][2]string{{low.ID, epic.ID}, {high.ID, epic.ID}, {nested.ID, high.ID}} {
+		if err := store.SetParent(ctx, link[0], link[1], "test"); err != nil {
+			t.Fatalf("SetParent(%s, %s) failed: %v", link[0], link[1], err)
+		}
+	}
+
+	children, err := store.GetChildren(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetChildren failed: %v", err)
+	}
+	if len(children) != 2 || children[0].ID != high.ID || children[1].ID != low.ID {
+		t.Errorf("Expected children [%s %s], got %v", high.ID, low.ID, issueIDs(children))
+	}
+
+	// Reparenting replaces the old parent
+	if err := store.SetParent(ctx, low.ID, other.ID, "test"); err != nil {
+		t.Fatalf("SetParent failed: %v", err)
+	}
+	if children, _ := store.GetChildren(ctx, epic.ID); len(children) != 1 || children[0].ID != high.ID {
+		t.Errorf("Expected only %s under the first epic, got %v", high.ID, issueIDs(children))
+	}
+
+	epicID := epic.ID
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{ParentID: &epicID})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != high.ID {
+		t.Errorf("Expected ParentID filter to match %s, got %v", high.ID, issueIDs(issues))
+	}
+
+	topLevel := ""
+	issues, err = store.SearchIssues(ctx, "", types.IssueFilter{ParentID: &topLevel})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected the two epics to be top-level, got %v", issueIDs(issues))
+	}
+
+	// Detaching makes the issue top-level again
+	if err := store.SetParent(ctx, low.ID, "", "test"); err != nil {
+		t.Fatalf("SetParent failed: %v", err)
+	}
+	if children, _ := store.GetChildren(ctx, other.ID); len(children) != 0 {
+		t.Errorf("Expected no children after detaching, got %v", issueIDs(children))
+	}
+}
+
+func TestSetParentRejectsCycles(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"Epic", "Story", "Task"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.SetParent(ctx, ids[1], ids[0], "test"); err != nil {
+		t.Fatalf("SetParent failed: %v", err)
+	}
+	if err := store.SetParent(ctx, ids[2], ids[1], "test"); err != nil {
+		t.Fatalf("SetParent failed: %v", err)
+	}
+
+	err := store.SetParent(ctx, ids[0], ids[0], "test")
+	var verr *types.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "parent_id" || !errors.Is(err, types.ErrInvalidField) {
+		t.Errorf("Expected a parent_id ValidationError making an issue its own parent, got %v", err)
+	}
+	if err := store.SetParent(ctx, ids[0], ids[2], "test"); !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("Expected ErrCycleDetected, got %v", err)
+	}
+	if err := store.SetParent(ctx, ids[0], "vc-9999", "test"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
+
+func issueIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
@@ -0,0 +1,252 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// GetFieldMetadata returns, for each mutable field, when it was last changed and by whom.
+// The metadata is derived from the event history. Fields that have not changed since
+// creation report the creation time and actor with Unchanged set.
+func (s *SQLiteStorage) GetFieldMetadata(ctx context.Context, id string) (map[string]types.FieldMeta, error) {
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	// Start every field at creation time; legacy issues without a creation event
+	// fall back to the row's created_at with no known actor
+	meta := make(map[string]types.FieldMeta, len(allowedUpdateFields))
+	for field := range allowedUpdateFields {
+		meta[field] = types.FieldMeta{ModifiedAt: issue.CreatedAt, Unchanged: true}
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT event_type, actor, new_value, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var eventType types.EventType
+		var actor string
+		var newValue sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&eventType, &actor, &newValue, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		if eventType == types.EventCreated {
+			for field := range meta {
+				meta[field] = types.FieldMeta{ModifiedAt: createdAt, ModifiedBy: actor, Unchanged: true}
+			}
+			continue
+		}
+
+		for _, field := range changedFields(eventType, newValue) {
+			meta[field] = types.FieldMeta{ModifiedAt: createdAt, ModifiedBy: actor}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return meta, nil
+}
+
+// changedFields returns the mutable fields touched by an event.
+// UpdateIssue stores the updates map as new_value; status-only events such as
+// CloseIssue and ClaimIssue carry no new_value but still change status.
+func changedFields(eventType types.EventType, newValue sql.NullString) []string {
+	if newValue.Valid && newValue.String != "" {
+		var updates map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(newValue.String), &updates); err == nil {
+			var fields []string
+			for field := range updates {
+				if allowedUpdateFields[field] {
+					fields = append(fields, field)
+				}
+			}
+			return fields
+		}
+	}
+
+	switch eventType {
+	case types.EventStatusChanged, types.EventClosed, types.EventReopened:
+		return []string{"status"}
+	}
+	return nil
+}
+
+// GetIssuesModifiedBy returns the distinct issues with at least one event by actor
+// (other than views) at or after since, most recently touched first. Issues the actor changed several
+// times appear once.
+func (s *SQLiteStorage) GetIssuesModifiedBy(ctx context.Context, actor string, since time.Time) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN (
+			SELECT e.issue_id, MAX(julianday(e.created_at)) AS last_touched
+			FROM events e
+			WHERE e.actor = ? AND e.event_type != ? AND julianday(e.created_at) >= julianday(?)
+			GROUP BY e.issue_id
+		) touched ON touched.issue_id = i.id
+		WHERE (? = '' OR i.project_id = ?)
+		ORDER BY touched.last_touched DESC, i.id ASC
+	`, actor, types.EventViewed, since.UTC(), s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues modified by %s: %w", actor, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
+
+// GetAssignmentHistory returns who an issue was assigned to and when, oldest first:
+// the assignee it was created with, if any, then each change recorded by an
+// EventAssigned (or, for older history, an update event that set the assignee).
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) GetAssignmentHistory(ctx context.Context, issueID string) ([]*types.Assignment, error) {
+	issue, err := s.getIssue(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT actor, new_value, created_at
+		FROM events
+		WHERE issue_id = ? AND new_value IS NOT NULL
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var history []*types.Assignment
+	for rows.Next() {
+		var actor, newValue string
+		var createdAt time.Time
+		if err := rows.Scan(&actor, &newValue, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		// Creation events hold the whole issue, which omits an empty assignee
+		var fields struct {
+			Assignee *string `json:"assignee"`
+		}
+		if json.Unmarshal([]byte(newValue), &fields) != nil || fields.Assignee == nil {
+			continue
+		}
+		history = append(history, &types.Assignment{Assignee: *fields.Assignee, AssignedAt: createdAt, AssignedBy: actor})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+	return history, nil
+}
+
+// issueHistory is the JSON document written by ExportIssueHistory
+type issueHistory struct {
+	ExportedAt time.Time      `json:"exported_at"`
+	Issue      *types.Issue   `json:"issue"`
+	Events     []historyEvent `json:"events"`
+}
+
+// historyEvent is an event with its old and new values parsed into per-field changes
+type historyEvent struct {
+	*types.Event
+	Changes map[string]fieldChange `json:"changes,omitempty"`
+}
+
+// fieldChange is one field's value before and after an event; Old is nil for
+// fields the event set for the first time
+type fieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// ExportIssueHistory writes one issue and its complete event history, oldest first,
+// to w as a JSON document, for archiving or sharing a single issue's record. Each
+// event's JSON old and new values are parsed into the fields it changed. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) ExportIssueHistory(ctx context.Context, id string, w io.Writer) error {
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	events, err := s.GetEventTimeline(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	h := issueHistory{ExportedAt: s.now(), Issue: issue, Events: make([]historyEvent, 0, len(events))}
+	for _, event := range events {
+		changes, err := s.eventChanges(event)
+		if err != nil {
+			return err
+		}
+		h.Events = append(h.Events, historyEvent{Event: event, Changes: changes})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(h); err != nil {
+		return fmt.Errorf("failed to write issue history: %w", err)
+	}
+	return nil
+}
+
+// eventChanges diffs an event's new value against its old value, field by field.
+// Events whose new value isn't a JSON object (such as comments) have no changes.
+// Encrypted columns are decrypted.
+func (s *SQLiteStorage) eventChanges(event *types.Event) (map[string]fieldChange, error) {
+	var newFields, oldFields map[string]interface{}
+	if event.NewValue == nil || json.Unmarshal([]byte(*event.NewValue), &newFields) != nil {
+		return nil, nil
+	}
+	if event.OldValue != nil {
+		_ = json.Unmarshal([]byte(*event.OldValue), &oldFields)
+	}
+
+	changes := make(map[string]fieldChange)
+	for field, newValue := range newFields {
+		oldValue := oldFields[field]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		change := fieldChange{Old: oldValue, New: newValue}
+		for _, value := range []*interface{}{&change.Old, &change.New} {
+			if text, ok := (*value).(string); ok {
+				plain, err := s.cipher.decrypt(text)
+				if err != nil {
+					return nil, err
+				}
+				*value = plain
+			}
+		}
+		changes[field] = change
+	}
+	return changes, nil
+}
@@ -0,0 +1,279 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// setEventTimes rewrites the created_at of an issue's events, in insertion order.
+// Events are timestamped with second resolution, so tests that care about
+// ordering set explicit times instead of sleeping.
+func setEventTimes(t *testing.T, store *SQLiteStorage, issueID string, times ...time.Time) {
+	t.Helper()
+
+	rows, err := store.db.Query(`SELECT id FROM events WHERE issue_id = ? ORDER BY id ASC`, issueID)
+	if err != nil {
+		t.Fatalf("Failed to query events: %v", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("Failed to scan event id: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+
+	if len(ids) != len(times) {
+		t.Fatalf("Expected %d events for %s, got %d", len(times), issueID, len(ids))
+	}
+	for i, id := range ids {
+		if _, err := store.db.Exec(`UPDATE events SET created_at = ? WHERE id = ?`, times[i], id); err != nil {
+			t.Fatalf("Failed to set event time: %v", err)
+		}
+	}
+}
+
+func TestGetFieldMetadata(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Field metadata",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "creator"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "alice"); err != nil {
+		t.Fatalf("Failed to update priority: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "bob"); err != nil {
+		t.Fatalf("Failed to update title: %v", err)
+	}
+
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	priorityChanged := created.Add(time.Hour)
+	titleChanged := created.Add(2 * time.Hour)
+	setEventTimes(t, store, issue.ID, created, priorityChanged, titleChanged)
+
+	meta, err := store.GetFieldMetadata(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetFieldMetadata failed: %v", err)
+	}
+
+	priority := meta["priority"]
+	if priority.Unchanged || priority.ModifiedBy != "alice" || !priority.ModifiedAt.Equal(priorityChanged) {
+		t.Errorf("Unexpected priority metadata: %+v", priority)
+	}
+
+	title := meta["title"]
+	if title.Unchanged || title.ModifiedBy != "bob" || !title.ModifiedAt.Equal(titleChanged) {
+		t.Errorf("Unexpected title metadata: %+v", title)
+	}
+
+	description := meta["description"]
+	if !description.Unchanged || description.ModifiedBy != "creator" || !description.ModifiedAt.Equal(created) {
+		t.Errorf("Expected description to be unchanged since creation, got %+v", description)
+	}
+
+	if _, err := store.GetFieldMetadata(ctx, "vc-9999"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
+
+func TestGetIssuesModifiedBy(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"First", "Second", "Untouched"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "creator"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	// alice touches the first issue twice and the second once; bob touches the third
+	for _, update := range []struct {
+		issue    *types.Issue
+		priority int
+		actor    string
+	}{
+		{issues[0], 1, "alice"},
+		{issues[0], 0, "alice"},
+		{issues[1], 1, "alice"},
+		{issues[2], 1, "bob"},
+	} {
+		if err := store.UpdateIssue(ctx, update.issue.ID, map[string]interface{}{"priority": update.priority}, update.actor); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	setEventTimes(t, store, issues[0].ID, base, base.Add(time.Hour), base.Add(2*time.Hour))
+	setEventTimes(t, store, issues[1].ID, base, base.Add(3*time.Hour))
+	setEventTimes(t, store, issues[2].ID, base, base.Add(time.Hour))
+
+	got, err := store.GetIssuesModifiedBy(ctx, "alice", base)
+	if err != nil {
+		t.Fatalf("GetIssuesModifiedBy failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 distinct issues, got %d", len(got))
+	}
+	if got[0].ID != issues[1].ID || got[1].ID != issues[0].ID {
+		t.Errorf("Expected most recently touched first, got %s, %s", got[0].ID, got[1].ID)
+	}
+
+	// The window excludes older changes
+	got, err = store.GetIssuesModifiedBy(ctx, "alice", base.Add(150*time.Minute))
+	if err != nil {
+		t.Fatalf("GetIssuesModifiedBy failed: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != issues[1].ID {
+		t.Errorf("Expected only %s in the window, got %d issues", issues[1].ID, len(got))
+	}
+}
+
+func TestExportIssueHistory(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Archived", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0, "assignee": "bob"}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "bob", "on it"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "fixed", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := store.ExportIssueHistory(ctx, issue.ID, &buf); err != nil {
+		t.Fatalf("ExportIssueHistory failed: %v", err)
+	}
+
+	var h issueHistory
+	if err := json.Unmarshal(buf.Bytes(), &h); err != nil {
+		t.Fatalf("Failed to decode history: %v", err)
+	}
+	if h.Issue == nil || h.Issue.ID != issue.ID || h.Issue.Status != types.StatusClosed {
+		t.Fatalf("Expected the closed issue, got %+v", h.Issue)
+	}
+
+	want, _ := store.GetEventTimeline(ctx, issue.ID)
+	if len(h.Events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(h.Events))
+	}
+	for i, e := range h.Events {
+		if e.ID != want[i].ID || e.EventType != want[i].EventType || e.Actor != want[i].Actor {
+			t.Errorf("Event %d differs: got %+v, want %+v", i, e.Event, want[i])
+		}
+	}
+
+	var update, assigned *historyEvent
+	for i := range h.Events {
+		switch h.Events[i].EventType {
+		case types.EventUpdated:
+			update = &h.Events[i]
+		case types.EventAssigned:
+			assigned = &h.Events[i]
+		}
+	}
+	if update == nil || assigned == nil {
+		t.Fatal("Expected updated and assigned events")
+	}
+	if len(update.Changes) != 1 {
+		t.Errorf("Expected a change to priority, got %v", update.Changes)
+	}
+	if c := update.Changes["priority"]; c.Old != float64(2) || c.New != float64(0) {
+		t.Errorf("Expected priority 2 -> 0, got %v -> %v", c.Old, c.New)
+	}
+	if c := assigned.Changes["assignee"]; c.New != "bob" {
+		t.Errorf("Expected assignee -> bob, got %v", c.New)
+	}
+
+	if err := store.ExportIssueHistory(ctx, "vc-9999", &buf); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
+
+func TestGetAssignmentHistory(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Handoffs", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "alice"}
+	if err := store.CreateIssue(ctx, issue, "creator"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for _, step := range []struct {
+		updates map[string]interface{}
+		actor   string
+	}{
+		{map[string]interface{}{"assignee": "bob", "priority": 1}, "alice"},
+		{map[string]interface{}{"notes": "no handoff"}, "bob"},
+		{map[string]interface{}{"assignee": ""}, "lead"},
+		{map[string]interface{}{"assignee": "carol"}, "lead"},
+	} {
+		if err := store.UpdateIssue(ctx, issue.ID, step.updates, step.actor); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var assignedEvents int
+	for _, e := range events {
+		if e.EventType != types.EventAssigned {
+			continue
+		}
+		assignedEvents++
+		if e.OldValue == nil || e.NewValue == nil {
+			t.Errorf("Expected old and new assignee on %+v", e)
+		}
+	}
+	if assignedEvents != 3 {
+		t.Errorf("Expected 3 assigned events, got %d", assignedEvents)
+	}
+
+	history, err := store.GetAssignmentHistory(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetAssignmentHistory failed: %v", err)
+	}
+	want := []types.Assignment{
+		{Assignee: "alice", AssignedBy: "creator"},
+		{Assignee: "bob", AssignedBy: "alice"},
+		{Assignee: "", AssignedBy: "lead"},
+		{Assignee: "carol", AssignedBy: "lead"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("Expected %d assignments, got %d", len(want), len(history))
+	}
+	for i, a := range history {
+		if a.Assignee != want[i].Assignee || a.AssignedBy != want[i].AssignedBy || a.AssignedAt.IsZero() {
+			t.Errorf("Assignment %d = %+v, want %s by %s", i, a, want[i].Assignee, want[i].AssignedBy)
+		}
+	}
+
+	if _, err := store.GetAssignmentHistory(ctx, "vc-9999"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// Hooks are optional callbacks around issue lifecycle operations (see WithHooks).
+// Any of them may be nil.
+//
+// Before hooks run before the operation's transaction starts. Returning an error
+// aborts the operation, and the error is returned to the caller wrapped.
+// OnBeforeCreate may modify the issue; it runs after configured defaults are applied
+// and before validation, so its changes are validated too.
+//
+// After hooks run once the transaction has committed, so they see the final state
+// but can't undo it. They aren't called when the operation fails or changes nothing.
+type Hooks struct {
+	OnBeforeCreate func(ctx context.Context, issue *types.Issue, actor string) error
+	OnAfterCreate  func(ctx context.Context, issue *types.Issue, actor string)
+
+	// Update hooks see only the fields that actually change
+	OnBeforeUpdate func(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+	OnAfterUpdate  func(ctx context.Context, id string, updates map[string]interface{}, actor string)
+
+	OnBeforeClose func(ctx context.Context, id, reason, actor string) error
+	OnAfterClose  func(ctx context.Context, id, reason, actor string)
+
+	// OnWatchersNotify runs after an issue changes with the issue's current watchers,
+	// so a higher layer can send notifications. eventType is the event the change
+	// recorded: EventUpdated, EventStatusChanged, EventClosed or EventAssigned from
+	// UpdateIssue (once per event it records, and not at all if it records none),
+	// EventCommented, and the events of closing, reopening, blocking, archiving and
+	// approving. It isn't called for issues nobody watches. The watchers include the
+	// actor if they watch the issue.
+	OnWatchersNotify func(ctx context.Context, id string, watchers []string, eventType types.EventType, actor string)
+}
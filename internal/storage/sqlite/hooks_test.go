@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestHooks(t *testing.T) {
+	errForbidden := errors.New("forbidden word")
+	var closed []string
+	store := setupTestDB(t, WithHooks(Hooks{
+		OnBeforeCreate: func(ctx context.Context, issue *types.Issue, actor string) error {
+			if strings.Contains(strings.ToLower(issue.Title), "yolo") {
+				return errForbidden
+			}
+			return nil
+		},
+		OnAfterClose: func(ctx context.Context, id, reason, actor string) {
+			closed = append(closed, id+":"+reason)
+		},
+	}))
+	ctx := context.Background()
+
+	rejected := &types.Issue{Title: "YOLO deploy to prod", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, rejected, "test"); !errors.Is(err, errForbidden) {
+		t.Fatalf("Expected the before-create hook to reject the issue, got %v", err)
+	}
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected nothing to be created, got %d issues", len(issues))
+	}
+
+	accepted := &types.Issue{Title: "Careful deploy", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, accepted, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// After hooks run only once the close has committed
+	if err := store.CloseIssue(ctx, accepted.ID, "shipped", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if len(closed) != 1 || closed[0] != accepted.ID+":shipped" {
+		t.Errorf("Expected one after-close call for %s, got %v", accepted.ID, closed)
+	}
+}
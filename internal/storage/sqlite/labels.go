@@ -7,8 +7,22 @@ import (
 	"github.com/steveyegge/vc/internal/types"
 )
 
-// AddLabel adds a label to an issue
+// AddLabel adds a label to an issue. Labels are trimmed and lowercased, so adding
+// "Bug" to an issue labeled "bug" is a no-op. Returns ErrIssueNotFound if the issue
+// doesn't exist.
 func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	label = normalizeLabel(label)
+	if label == "" {
+		return types.NewValidationError("label", label, types.ErrInvalidField, "label cannot be empty")
+	}
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -31,9 +45,9 @@ func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor stri
 
 	if rowsAffected > 0 {
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, issueID, types.EventLabelAdded, actor, fmt.Sprintf("Added label: %s", label))
+			INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, issueID, types.EventLabelAdded, actor, fmt.Sprintf("Added label: %s", label), s.now())
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
 		}
@@ -42,8 +56,17 @@ func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor stri
 	return tx.Commit()
 }
 
-// RemoveLabel removes a label from an issue
+// RemoveLabel removes a label from an issue, matching it the way AddLabel stores it
 func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	label = normalizeLabel(label)
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -65,9 +88,9 @@ func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 
 	if rowsAffected > 0 {
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, issueID, types.EventLabelRemoved, actor, fmt.Sprintf("Removed label: %s", label))
+			INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, issueID, types.EventLabelRemoved, actor, fmt.Sprintf("Removed label: %s", label), s.now())
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
 		}
@@ -79,8 +102,11 @@ func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 // GetLabels returns all labels for an issue
 func (s *SQLiteStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT label FROM labels WHERE issue_id = ? ORDER BY label
-	`, issueID)
+		SELECT label FROM labels
+		WHERE issue_id = ?
+		  AND (? = '' OR issue_id IN (SELECT id FROM issues WHERE project_id = ?))
+		ORDER BY label
+	`, issueID, s.opts.project, s.opts.project)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get labels: %w", err)
 	}
@@ -101,18 +127,85 @@ func (s *SQLiteStorage) GetLabels(ctx context.Context, issueID string) ([]string
 // GetIssuesByLabel returns issues with a specific label
 func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
-		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at
+		SELECT `+issueColumns+`
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
 		ORDER BY i.priority ASC, i.created_at DESC
-	`, label)
+	`, normalizeLabel(label))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issues by label: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	return scanIssues(rows)
+	return s.scanIssues(rows)
+}
+
+// GetLabelCounts returns each label with the number of non-closed issues carrying it.
+// Labels used only on closed issues are omitted.
+func (s *SQLiteStorage) GetLabelCounts(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT l.label, COUNT(*)
+		FROM labels l
+		JOIN issues i ON i.id = l.issue_id
+		WHERE i.status != ? AND (? = '' OR i.project_id = ?)
+		GROUP BY l.label
+	`, types.StatusClosed, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var label string
+		var count int
+		if err := rows.Scan(&label, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan label count: %w", err)
+		}
+		counts[label] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating label counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetRelatedByLabels returns non-closed issues sharing at least minShared labels with
+// the given issue, most shared labels first, then by priority. The issue itself is
+// excluded. Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) GetRelatedByLabels(ctx context.Context, id string, minShared int) ([]*types.Issue, error) {
+	if minShared < 1 {
+		return nil, fmt.Errorf("minShared must be at least 1, got %d", minShared)
+	}
+
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN (
+			SELECT other.issue_id, COUNT(*) AS shared
+			FROM labels mine
+			JOIN labels other ON other.label = mine.label AND other.issue_id != mine.issue_id
+			WHERE mine.issue_id = ?
+			GROUP BY other.issue_id
+			HAVING COUNT(*) >= ?
+		) related ON related.issue_id = i.id
+		WHERE i.status != ? AND (? = '' OR i.project_id = ?)
+		ORDER BY related.shared DESC, i.priority ASC, i.created_at DESC
+	`, id, minShared, types.StatusClosed, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
 }
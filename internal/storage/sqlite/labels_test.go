@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/steveyegge/vc/internal/types"
@@ -42,9 +43,9 @@ func TestAddLabelSkipsEventWhenLabelExists(t *testing.T) {
 	if len(events) != 2 {
 		t.Errorf("Expected 2 events after first add, got %d", len(events))
 	}
-	// Verify the second event is label_added
-	if events[1].EventType != types.EventLabelAdded {
-		t.Errorf("Expected EventLabelAdded, got %s", events[1].EventType)
+	// Verify the newest event is label_added
+	if events[0].EventType != types.EventLabelAdded {
+		t.Errorf("Expected EventLabelAdded, got %s", events[0].EventType)
 	}
 
 	// Add the same label again - should NOT record event
@@ -152,13 +153,13 @@ func TestRemoveLabelSkipsEventWhenLabelDoesntExist(t *testing.T) {
 		}
 	}
 
-	// Verify the events are in correct order
+	// Verify the events are in correct order, newest first
+	if events[0].EventType != types.EventLabelRemoved {
+		t.Errorf("Expected newest event to be EventLabelRemoved, got %s", events[0].EventType)
+	}
 	if events[1].EventType != types.EventLabelAdded {
 		t.Errorf("Expected second event to be EventLabelAdded, got %s", events[1].EventType)
 	}
-	if events[2].EventType != types.EventLabelRemoved {
-		t.Errorf("Expected third event to be EventLabelRemoved, got %s", events[2].EventType)
-	}
 
 	// Verify no labels remain
 	labels, err := store.GetLabels(ctx, issue.ID)
@@ -383,3 +384,168 @@ func TestSearchIssuesWithLabels(t *testing.T) {
 		t.Errorf("Expected 0 issues with nonexistent label, got %d", len(results))
 	}
 }
+
+func TestGetLabelCounts(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	seed := []struct {
+		status types.Status
+		labels []string
+	}{
+		{types.StatusOpen, []string{"backend", "urgent"}},
+		{types.StatusInProgress, []string{"backend"}},
+		{types.StatusClosed, []string{"backend", "legacy"}},
+	}
+	for _, sd := range seed {
+		issue := &types.Issue{Title: "Labeled", Status: sd.status, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range sd.labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+	}
+
+	counts, err := store.GetLabelCounts(ctx)
+	if err != nil {
+		t.Fatalf("GetLabelCounts failed: %v", err)
+	}
+	if len(counts) != 2 || counts["backend"] != 2 || counts["urgent"] != 1 {
+		t.Errorf("Expected backend=2 urgent=1 with closed issues excluded, got %v", counts)
+	}
+}
+
+// TestLabelNormalization verifies labels are trimmed and lowercased on every path,
+// so "Bug" and "bug" can't both exist on an issue
+func TestLabelNormalization(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Normalized", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	for _, label := range []string{"Bug", "  bug ", "BUG", "Backend"} {
+		if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+			t.Fatalf("AddLabel(%q) failed: %v", label, err)
+		}
+	}
+	if err := store.AddLabel(ctx, issue.ID, "   ", "test"); err == nil {
+		t.Error("Expected an error for a blank label")
+	}
+
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != "backend" || labels[1] != "bug" {
+		t.Errorf("Expected [backend bug], got %v", labels)
+	}
+
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{"BUG", " Backend"}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 1 {
+		t.Errorf("Expected the label filter to match case-insensitively, got %d issues", len(found))
+	}
+
+	if err := store.RemoveLabel(ctx, issue.ID, "Bug", "test"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+	labels, _ = store.GetLabels(ctx, issue.ID)
+	if len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("Expected [backend] after removing Bug, got %v", labels)
+	}
+}
+
+func TestMigrateLabelCase(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Legacy labels", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	// Rows written before normalization
+	for _, label := range []string{"Bug", "bug", "Frontend "} {
+		if _, err := store.db.Exec(`INSERT INTO labels (issue_id, label) VALUES (?, ?)`, issue.ID, label); err != nil {
+			t.Fatalf("Failed to insert label: %v", err)
+		}
+	}
+
+	if err := migrateLabelCase(store.db); err != nil {
+		t.Fatalf("migrateLabelCase failed: %v", err)
+	}
+
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != "bug" || labels[1] != "frontend" {
+		t.Errorf("Expected [bug frontend], got %v", labels)
+	}
+}
+
+func TestGetRelatedByLabels(t *testing.T) {
+	store := setupTestDB(t)
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	create := func(title string, priority int, labels ...string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+
+	base := create("Base", 2, "auth", "backend", "security")
+	one := create("Shares one", 0, "auth")
+	three := create("Shares three", 3, "auth", "backend", "security", "ui")
+	two := create("Shares two", 2, "backend", "security")
+	closed := create("Closed, shares three", 1, "auth", "backend", "security")
+	create("Unrelated", 1, "docs")
+	if err := store.CloseIssue(ctx, closed.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	related, err := store.GetRelatedByLabels(ctx, base.ID, 1)
+	if err != nil {
+		t.Fatalf("GetRelatedByLabels failed: %v", err)
+	}
+	want := []string{three.ID, two.ID, one.ID}
+	if len(related) != len(want) {
+		t.Fatalf("got %d related issues, want %d", len(related), len(want))
+	}
+	for i, issue := range related {
+		if issue.ID != want[i] {
+			t.Errorf("related[%d] = %s, want %s", i, issue.ID, want[i])
+		}
+	}
+
+	related, err = store.GetRelatedByLabels(ctx, base.ID, 2)
+	if err != nil {
+		t.Fatalf("GetRelatedByLabels failed: %v", err)
+	}
+	if len(related) != 2 || related[0].ID != three.ID || related[1].ID != two.ID {
+		t.Errorf("minShared=2 returned %d issues", len(related))
+	}
+
+	if _, err := store.GetRelatedByLabels(ctx, "vc-999", 1); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("expected ErrIssueNotFound, got %v", err)
+	}
+	if _, err := store.GetRelatedByLabels(ctx, base.ID, 0); err == nil {
+		t.Error("expected minShared 0 to be rejected")
+	}
+}
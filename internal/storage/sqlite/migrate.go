@@ -0,0 +1,233 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/storage/migrations"
+)
+
+// SchemaVersion is the schema version New brings databases up to. Tools can compare
+// it with CurrentSchemaVersion to check that a database is compatible.
+const SchemaVersion = 9
+
+// schemaMigrations are the versioned schema changes New applies in order, each in
+// its own transaction that also records its version in the schema_version table.
+// New changes, such as new columns and their indexes, belong here as a new
+// migration with SchemaVersion bumped to match, so that they apply exactly once.
+//
+// Version 1 is the baseline. Versions 2 to 9 add the issues columns that came after
+// it. The schema already declares them for new databases, and databases from before
+// versioning had them added by an unversioned pass on every New, so each of these
+// adds its column only if it's missing (see addIssueColumn).
+var schemaMigrations = []migrations.Migration{
+	{Version: 1, Description: "Baseline schema"},
+	{
+		Version:     2,
+		Description: "Add issues.due_at",
+		UpFunc:      addIssueColumn("due_at", "DATETIME", `CREATE INDEX IF NOT EXISTS idx_issues_due_at ON issues(due_at)`),
+		Down:        `DROP INDEX IF EXISTS idx_issues_due_at; ALTER TABLE issues DROP COLUMN due_at`,
+	},
+	{
+		Version:     3,
+		Description: "Add issues.estimate_min_minutes",
+		UpFunc:      addIssueColumn("estimate_min_minutes", "INTEGER"),
+		Down:        `ALTER TABLE issues DROP COLUMN estimate_min_minutes`,
+	},
+	{
+		Version:     4,
+		Description: "Add issues.estimate_max_minutes",
+		UpFunc:      addIssueColumn("estimate_max_minutes", "INTEGER"),
+		Down:        `ALTER TABLE issues DROP COLUMN estimate_max_minutes`,
+	},
+	{
+		Version:     5,
+		Description: "Add issues.project_id",
+		UpFunc:      addIssueColumn("project_id", "TEXT NOT NULL DEFAULT ''", `CREATE INDEX IF NOT EXISTS idx_issues_project ON issues(project_id)`),
+		Down:        `DROP INDEX IF EXISTS idx_issues_project; ALTER TABLE issues DROP COLUMN project_id`,
+	},
+	{
+		Version:     6,
+		Description: "Add issues.pinned",
+		UpFunc:      addIssueColumn("pinned", "INTEGER NOT NULL DEFAULT 0"),
+		Down:        `ALTER TABLE issues DROP COLUMN pinned`,
+	},
+	{
+		Version:     7,
+		Description: "Add issues.blocked_reason",
+		UpFunc:      addIssueColumn("blocked_reason", "TEXT"),
+		Down:        `ALTER TABLE issues DROP COLUMN blocked_reason`,
+	},
+	{
+		Version:     8,
+		Description: "Add issues.reviewer",
+		UpFunc:      addIssueColumn("reviewer", "TEXT NOT NULL DEFAULT ''", `CREATE INDEX IF NOT EXISTS idx_issues_reviewer ON issues(reviewer)`),
+		Down:        `DROP INDEX IF EXISTS idx_issues_reviewer; ALTER TABLE issues DROP COLUMN reviewer`,
+	},
+	{
+		Version:     9,
+		Description: "Add issues.archived_at",
+		UpFunc:      addIssueColumn("archived_at", "DATETIME"),
+		Down:        `ALTER TABLE issues DROP COLUMN archived_at`,
+	},
+}
+
+// addIssueColumn returns a migration that adds a column to the issues table unless
+// it already exists, then runs indexes, which should use IF NOT EXISTS
+func addIssueColumn(column, decl string, indexes ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		exists, err := columnExists(tx, "issues", column)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE issues ADD COLUMN %s %s", column, decl)); err != nil {
+				return fmt.Errorf("failed to add column issues.%s: %w", column, err)
+			}
+		}
+		for _, stmt := range indexes {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to create index: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+// applySchemaMigrations applies the schemaMigrations the database hasn't recorded yet
+func applySchemaMigrations(db *sql.DB) error {
+	manager := migrations.NewManager()
+	for _, migration := range schemaMigrations {
+		manager.Register(migration)
+	}
+	return manager.ApplySQLite(db)
+}
+
+// CurrentSchemaVersion returns the latest schema migration applied to the database,
+// or 0 if none has been. A version above SchemaVersion means the database was
+// migrated by a newer build.
+func (s *SQLiteStorage) CurrentSchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	return version, nil
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// columnExists reports whether a table has the named column
+func columnExists(db querier, table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return false, fmt.Errorf("failed to scan column info: %w", err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// legacyPriorityCheck is the issues.priority constraint from before the upper bound
+// became configurable (see WithMaxPriority); the bound is now enforced in Go
+const legacyPriorityCheck = "CHECK(priority >= 0 AND priority <= 4)"
+
+// migratePriorityCheck drops the hardcoded upper bound from the priority CHECK
+// constraint of older databases. Removing a CHECK constraint doesn't change stored
+// data, so this edits the table definition in place with writable_schema, as
+// described in https://www.sqlite.org/lang_altertable.html#otheralter, instead of
+// rebuilding the issues table.
+func migratePriorityCheck(db *sql.DB) error {
+	var tableSQL string
+	if err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'issues'`).Scan(&tableSQL); err != nil {
+		return fmt.Errorf("failed to read issues table definition: %w", err)
+	}
+	if !strings.Contains(tableSQL, legacyPriorityCheck) {
+		return nil
+	}
+
+	// The pragmas and the schema update must share one connection
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	var schemaVersion int
+	if err := conn.QueryRowContext(ctx, "PRAGMA schema_version").Scan(&schemaVersion); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA writable_schema = ON"); err != nil {
+		return fmt.Errorf("failed to enable writable_schema: %w", err)
+	}
+	_, err = conn.ExecContext(ctx, `
+		UPDATE sqlite_master SET sql = replace(sql, ?, 'CHECK(priority >= 0)')
+		WHERE type = 'table' AND name = 'issues'
+	`, legacyPriorityCheck)
+	if err != nil {
+		return fmt.Errorf("failed to update issues table definition: %w", err)
+	}
+	// Bumping the schema version makes other connections reload the definition
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("PRAGMA schema_version = %d", schemaVersion+1)); err != nil {
+		return fmt.Errorf("failed to bump schema version: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA writable_schema = OFF"); err != nil {
+		return fmt.Errorf("failed to disable writable_schema: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// migrateLabelCase folds labels written before labels were normalized into their
+// normalized form, merging labels that differ only in case or surrounding spaces.
+// SQLite's lower() folds ASCII only, which covers the labels in practice.
+func migrateLabelCase(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`
+		INSERT OR IGNORE INTO labels (issue_id, label)
+		SELECT issue_id, lower(trim(label)) FROM labels
+		WHERE label != lower(trim(label)) AND trim(label) != ''
+	`); err != nil {
+		return fmt.Errorf("failed to normalize labels: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM labels WHERE label != lower(trim(label))`); err != nil {
+		return fmt.Errorf("failed to remove unnormalized labels: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,232 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/storage/migrations"
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// TestMigrateIssueColumns verifies columns added after the original schema are
+// added to databases created before them
+func TestMigrateIssueColumns(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test-*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpfile.Name()) }()
+	_ = tmpfile.Close()
+
+	// Create an issues table with only the original columns
+	db, err := sql.Open("sqlite3", tmpfile.Name())
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE issues (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			design TEXT NOT NULL DEFAULT '',
+			acceptance_criteria TEXT NOT NULL DEFAULT '',
+			notes TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL DEFAULT 'open',
+			priority INTEGER NOT NULL DEFAULT 2,
+			issue_type TEXT NOT NULL DEFAULT 'task',
+			assignee TEXT,
+			estimated_minutes INTEGER,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			closed_at DATETIME,
+			approved_at DATETIME,
+			approved_by TEXT
+		)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	_ = db.Close()
+
+	store, err := New(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("Failed to open legacy database: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, col := range []string{
+		"due_at", "estimate_min_minutes", "estimate_max_minutes", "project_id",
+		"pinned", "blocked_reason", "reviewer", "archived_at",
+	} {
+		exists, err := columnExists(store.db, "issues", col)
+		if err != nil {
+			t.Fatalf("columnExists failed: %v", err)
+		}
+		if !exists {
+			t.Errorf("Expected column %s to be added", col)
+		}
+	}
+	if version, _ := store.CurrentSchemaVersion(context.Background()); version != SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", SchemaVersion, version)
+	}
+}
+
+// TestSchemaMigrationDueAtIndex verifies the column migrations run on a database
+// left at version 1 by an older build, which already has the columns, and that
+// version 2 indexes due_at
+func TestSchemaMigrationDueAtIndex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "vc.db")
+
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	// Roll the database back to what a version 1 build left behind
+	if _, err := store.db.Exec(`DROP INDEX idx_issues_due_at`); err != nil {
+		t.Fatalf("Failed to drop index: %v", err)
+	}
+	if _, err := store.db.Exec(`DELETE FROM schema_version WHERE version > 1`); err != nil {
+		t.Fatalf("Failed to reset schema version: %v", err)
+	}
+	if version, _ := store.CurrentSchemaVersion(ctx); version != 1 {
+		t.Fatalf("Expected schema version 1, got %d", version)
+	}
+	_ = store.Close()
+
+	store, err = New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if version, _ := store.CurrentSchemaVersion(ctx); version != SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", SchemaVersion, version)
+	}
+	var count int
+	err = store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_issues_due_at'`).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to look up index: %v", err)
+	}
+	if count != 1 {
+		t.Error("Expected version 2 to create idx_issues_due_at")
+	}
+}
+
+// TestMigratePriorityCheck verifies older databases drop the hardcoded priority
+// upper bound so a wider WithMaxPriority range can be stored
+func TestMigratePriorityCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vc.db")
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE issues (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			design TEXT NOT NULL DEFAULT '',
+			acceptance_criteria TEXT NOT NULL DEFAULT '',
+			notes TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL DEFAULT 'open',
+			priority INTEGER NOT NULL DEFAULT 2 CHECK(priority >= 0 AND priority <= 4),
+			issue_type TEXT NOT NULL DEFAULT 'task',
+			assignee TEXT,
+			estimated_minutes INTEGER,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			closed_at DATETIME,
+			approved_at DATETIME,
+			approved_by TEXT
+		)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	_ = db.Close()
+
+	store, err := New(path, WithMaxPriority(10))
+	if err != nil {
+		t.Fatalf("Failed to open legacy database: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	issue := &types.Issue{Title: "Low priority", Status: types.StatusOpen, Priority: 7, IssueType: types.TypeTask}
+	if err := store.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("Expected priority 7 to be stored after migration, got %v", err)
+	}
+	if _, err := store.db.Exec(`UPDATE issues SET priority = -1 WHERE id = ?`, issue.ID); err == nil {
+		t.Error("Expected the lower bound to still be enforced")
+	}
+}
+
+// TestSchemaMigrations verifies versioned migrations apply once, in order, to new
+// and existing databases, and that a failing one is rolled back
+func TestSchemaMigrations(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "vc.db")
+
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	version, err := store.CurrentSchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("CurrentSchemaVersion failed: %v", err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", SchemaVersion, version)
+	}
+	if last := schemaMigrations[len(schemaMigrations)-1].Version; last != SchemaVersion {
+		t.Errorf("Expected SchemaVersion to match the last migration %d, got %d", last, SchemaVersion)
+	}
+	_ = store.Close()
+
+	// A new migration applies to the existing database on the next open
+	original := schemaMigrations
+	t.Cleanup(func() { schemaMigrations = original })
+	schemaMigrations = append(original[:len(original):len(original)], migrations.Migration{
+		Version:     SchemaVersion + 1,
+		Description: "Add severity",
+		Up:          `ALTER TABLE issues ADD COLUMN severity TEXT NOT NULL DEFAULT ''`,
+	})
+	for i := 0; i < 2; i++ {
+		store, err = New(path)
+		if err != nil {
+			t.Fatalf("New failed on open %d: %v", i+1, err)
+		}
+		if version, _ := store.CurrentSchemaVersion(ctx); version != SchemaVersion+1 {
+			t.Errorf("Expected schema version %d, got %d", SchemaVersion+1, version)
+		}
+		if exists, _ := columnExists(store.db, "issues", "severity"); !exists {
+			t.Error("Expected the migration to add the severity column")
+		}
+		_ = store.Close()
+	}
+
+	// A failing migration leaves no partial change behind
+	schemaMigrations = append(schemaMigrations, migrations.Migration{
+		Version:     SchemaVersion + 2,
+		Description: "Broken",
+		Up:          `ALTER TABLE issues ADD COLUMN broken TEXT; SELECT * FROM no_such_table`,
+	})
+	if _, err := New(path); err == nil {
+		t.Fatal("Expected New to fail on a broken migration")
+	}
+	schemaMigrations = original
+	store, err = New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if version, _ := store.CurrentSchemaVersion(ctx); version != SchemaVersion+1 {
+		t.Errorf("Expected schema version to stay at %d, got %d", SchemaVersion+1, version)
+	}
+	if exists, _ := columnExists(store.db, "issues", "broken"); exists {
+		t.Error("Expected the failed migration to be rolled back")
+	}
+}
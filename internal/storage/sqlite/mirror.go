@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+)
+
+// mirrorExec replays a statement that succeeded on the primary against the mirror.
+// Failures are logged and otherwise ignored (see WithMirror).
+func (c *conn) mirrorExec(query string, args []driver.NamedValue) {
+	if c.mirror == nil {
+		return
+	}
+	// The mirror runs to completion even if the caller's context is canceled
+	// after the primary write succeeded
+	if _, err := c.mirror.ExecContext(context.Background(), query, args); err != nil {
+		c.opts.warnf("mirror write failed: %v: %s", err, strings.Join(strings.Fields(query), " "))
+	}
+}
+
+// mirroredTx ends the mirror's transaction the same way as the primary's
+type mirroredTx struct {
+	driver.Tx
+	conn *conn
+}
+
+func (t *mirroredTx) Commit() error {
+	if err := t.Tx.Commit(); err != nil {
+		t.conn.mirrorExec("ROLLBACK", nil)
+		return err
+	}
+	t.conn.mirrorExec("COMMIT", nil)
+	return nil
+}
+
+func (t *mirroredTx) Rollback() error {
+	t.conn.mirrorExec("ROLLBACK", nil)
+	return t.Tx.Rollback()
+}
@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestMirrorReceivesWrites(t *testing.T) {
+	dir := t.TempDir()
+	mirrorPath := filepath.Join(dir, "mirror", "vc.db")
+	var buf bytes.Buffer
+	store, err := New(filepath.Join(dir, "vc.db"), WithMirror(mirrorPath), WithLogger(log.New(&buf, "", 0)))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Mirrored", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "backup", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	for n := 0; n < 2; n++ {
+		if err := store.CreateIssue(ctx, &types.Issue{Title: "More", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	counters := readIssueCounters(t, store.db)
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Expected no mirror warnings, got %q", buf.String())
+	}
+
+	// The ID counters are mirrored too, so a failed-over mirror doesn't reuse IDs.
+	// Read them before New, which seeds an empty counter table from the issues.
+	raw, err := sql.Open("sqlite3", mirrorPath)
+	if err != nil {
+		t.Fatalf("Failed to open mirror: %v", err)
+	}
+	if got := readIssueCounters(t, raw); got != counters {
+		t.Errorf("Expected mirrored issue counters %q, got %q", counters, got)
+	}
+	_ = raw.Close()
+
+	mirror, err := New(mirrorPath)
+	if err != nil {
+		t.Fatalf("Failed to open mirror: %v", err)
+	}
+	defer func() { _ = mirror.Close() }()
+
+	got, err := mirror.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue on mirror failed: %v", err)
+	}
+	if got == nil || got.Title != "Mirrored" || got.Priority != 0 {
+		t.Fatalf("Expected the updated issue in the mirror, got %+v", got)
+	}
+	labels, err := mirror.GetLabels(ctx, issue.ID)
+	if err != nil || len(labels) != 1 || labels[0] != "backup" {
+		t.Errorf("Expected mirrored label, got %v, %v", labels, err)
+	}
+	events, err := mirror.GetEvents(ctx, issue.ID, 0)
+	if err != nil || len(events) != 3 {
+		t.Errorf("Expected 3 mirrored events, got %d, %v", len(events), err)
+	}
+}
+
+// readIssueCounters returns the issue_counters rows as "prefix=last_id" pairs
+func readIssueCounters(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	rows, err := db.Query(`SELECT prefix, last_id FROM issue_counters ORDER BY prefix`)
+	if err != nil {
+		t.Fatalf("Failed to read issue counters: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var pairs []string
+	for rows.Next() {
+		var prefix string
+		var lastID int
+		if err := rows.Scan(&prefix, &lastID); err != nil {
+			t.Fatalf("Failed to scan issue counter: %v", err)
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%d", prefix, lastID))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Failed to read issue counters: %v", err)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// TestReadAfterWriteWithMirror checks that a read right after a write sees it, on
+// whichever pooled connection it runs. Reads always go to the primary, never the
+// mirror, and every connection shares the primary's WAL.
+func TestReadAfterWriteWithMirror(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "vc.db"), WithMirror(filepath.Join(dir, "mirror.db")))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	// Pin a connection so reads can't all land on the one that wrote
+	pinned, err := store.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Conn failed: %v", err)
+	}
+	defer func() { _ = pinned.Close() }()
+
+	for n := 0; n < 20; n++ {
+		issue := &types.Issue{Title: "Fresh", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got == nil {
+			t.Fatalf("Issue %s not visible right after it was created", issue.ID)
+		}
+
+		var title string
+		if err := pinned.QueryRowContext(ctx, `SELECT title FROM issues WHERE id = ?`, issue.ID).Scan(&title); err != nil {
+			t.Fatalf("Issue %s not visible on another connection: %v", issue.ID, err)
+		}
+	}
+}
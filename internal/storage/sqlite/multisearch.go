@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/steveyegge/vc/internal/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// searchMultiParallelism bounds how many of SearchMulti's filters run at once, so a
+// large dashboard doesn't take every pooled connection
+const searchMultiParallelism = 4
+
+// SearchMulti runs several labeled filters, such as a dashboard's saved views, and
+// returns each one's SearchIssues results under its label. The filters run
+// concurrently, a few at a time. If any filter fails, SearchMulti returns its error
+// and no results.
+func (s *SQLiteStorage) SearchMulti(ctx context.Context, queries map[string]types.IssueFilter) (map[string][]*types.Issue, error) {
+	results := make(map[string][]*types.Issue, len(queries))
+	var mu sync.Mutex
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(searchMultiParallelism)
+	for label, filter := range queries {
+		g.Go(func() error {
+			issues, err := s.SearchIssues(ctx, "", filter)
+			if err != nil {
+				return fmt.Errorf("filter %s: %w", label, err)
+			}
+			mu.Lock()
+			results[label] = issues
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
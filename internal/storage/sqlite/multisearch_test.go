@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestSearchMulti(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	for _, issue := range []*types.Issue{
+		{Title: "Mine", Assignee: "alice", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{Title: "Mine too", Assignee: "alice", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask},
+		{Title: "Unowned", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug},
+		{Title: "Done", Assignee: "alice", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	alice, empty, urgent := "alice", "", 0
+	results, err := store.SearchMulti(ctx, map[string]types.IssueFilter{
+		"my-open":    {Assignee: &alice, Statuses: []types.Status{types.StatusOpen, types.StatusInProgress}},
+		"unassigned": {Assignee: &empty},
+		"urgent":     {Priority: &urgent},
+	})
+	if err != nil {
+		t.Fatalf("SearchMulti failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 result sets, got %d", len(results))
+	}
+
+	want := map[string][]string{
+		"my-open":    {"Mine too", "Mine"},
+		"unassigned": {"Unowned"},
+		"urgent":     {"Unowned"},
+	}
+	for label, titles := range want {
+		got := results[label]
+		if len(got) != len(titles) {
+			t.Errorf("%s: expected %d issues, got %d", label, len(titles), len(got))
+			continue
+		}
+		for i, title := range titles {
+			if got[i].Title != title {
+				t.Errorf("%s: expected %q at %d, got %q", label, title, i, got[i].Title)
+			}
+		}
+	}
+
+	// One bad filter fails the whole batch
+	if _, err := store.SearchMulti(ctx, map[string]types.IssueFilter{"bad": {SortBy: "bogus"}}); err == nil {
+		t.Error("Expected an error for an invalid filter")
+	}
+}
@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalizeTitle collapses whitespace runs to single spaces, drops other control
+// characters, and trims the result (see WithTitleNormalization)
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	pendingSpace := false
+	for _, r := range title {
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = b.Len() > 0
+		case unicode.IsControl(r):
+			// Dropped without separating the surrounding text
+		default:
+			if pendingSpace {
+				b.WriteByte(' ')
+				pendingSpace = false
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalizeLabel trims and lowercases a label so "Bug" and " bug" are the same label
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
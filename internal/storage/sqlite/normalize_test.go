@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Plain title", "Plain title"},
+		{"  padded\t", "padded"},
+		{"multi\n\nline\r\ntitle", "multi line title"},
+		{"tabs\t\tand  spaces", "tabs and spaces"},
+		{"bell\x07 and\x00 nul", "bell and nul"},
+		{"\x1b[31mred\x1b[0m", "[31mred[0m"},
+	}
+	for _, tt := range tests {
+		if got := normalizeTitle(tt.in); got != tt.want {
+			t.Errorf("normalizeTitle(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTitleNormalizationOption(t *testing.T) {
+	ctx := context.Background()
+
+	store := setupTestDB(t, WithTitleNormalization())
+	issue := &types.Issue{Title: "\tFix\tlogin\n\nbug  ", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Title != "Fix login bug" {
+		t.Errorf("Expected normalized title on create, got %q", got.Title)
+	}
+
+	updates := map[string]interface{}{"title": "Fix\r\nlogout bug"}
+	if err := store.UpdateIssue(ctx, issue.ID, updates, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Title != "Fix logout bug" {
+		t.Errorf("Expected normalized title on update, got %q", got.Title)
+	}
+	if updates["title"] != "Fix\r\nlogout bug" {
+		t.Errorf("Expected caller's updates map to be left untouched, got %q", updates["title"])
+	}
+
+	// Whitespace-only titles normalize to empty and fail validation
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": " \n\t"}, "test"); err == nil {
+		t.Error("Expected whitespace-only title to be rejected")
+	}
+
+	// Off by default
+	plain := setupTestDB(t)
+	raw := &types.Issue{Title: "keep\tas is", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := plain.CreateIssue(ctx, raw, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, _ = plain.GetIssue(ctx, raw.ID)
+	if got.Title != "keep\tas is" {
+		t.Errorf("Expected title to be stored unchanged by default, got %q", got.Title)
+	}
+}
@@ -0,0 +1,493 @@
+package sqlite
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// Option configures optional SQLiteStorage behavior when passed to New
+type Option func(*options)
+
+// options holds the configurable behavior of a SQLiteStorage.
+// The zero value preserves the default behavior.
+type options struct {
+	// assigneeRequired lists statuses that UpdateIssue will only transition
+	// into when the issue has a non-empty assignee
+	assigneeRequired map[types.Status]bool
+
+	// idPrefix overrides the ID prefix derived from the filename and config (see WithIDPrefix)
+	idPrefix string
+
+	// idChecksum appends a checksum character to generated IDs (see WithIDChecksum)
+	idChecksum bool
+
+	// hooks are the lifecycle callbacks registered with WithHooks
+	hooks Hooks
+
+	// unauditedFields are updated without recording events (see WithUnauditedFields)
+	unauditedFields map[string]bool
+
+	// autoAssignPool is the rotation used by CreateIssueAutoAssign (see WithAutoAssignPool)
+	autoAssignPool []string
+
+	// project scopes all issue reads and writes to a single project (see WithProjectScope)
+	project string
+
+	// defaultSort is the ORDER BY clause SearchIssues uses when the filter specifies
+	// no sort (see WithDefaultSort); empty means priority, then newest first
+	defaultSort string
+
+	// typePriorities maps issue types to the priority CreateIssue gives issues created
+	// with types.PriorityUnset (see WithTypeDefaultPriorities)
+	typePriorities map[types.IssueType]int
+
+	// defaultIssueType is applied by CreateIssue to issues with no type (see WithDefaultIssueType)
+	defaultIssueType types.IssueType
+
+	// normalizeTitles cleans up titles on write (see WithTitleNormalization)
+	normalizeTitles bool
+
+	// skipCheckpointOnClose disables the WAL checkpoint Close runs by default
+	skipCheckpointOnClose bool
+
+	// clock returns the current time; nil means time.Now (see WithClock)
+	clock func() time.Time
+
+	// busyRetry controls retrying busy write transactions; the zero value doesn't retry
+	busyRetry RetryPolicy
+
+	// wipLimit caps each assignee's in_progress issues; 0 means no limit
+	// unless overridden per assignee (see WithWIPLimit)
+	wipLimit int
+
+	// slaPaused lists statuses whose time GetSLAElapsed doesn't count
+	slaPaused map[types.Status]bool
+
+	// checklistGatedClose makes closing refuse issues with unfinished checklist items
+	checklistGatedClose bool
+
+	// connMaxIdleTime and connMaxLifetime tune the connection pool; zero leaves
+	// database/sql's default of never expiring connections
+	connMaxIdleTime time.Duration
+	connMaxLifetime time.Duration
+
+	// logger receives warnings; nil writes them to stderr (see WithLogger)
+	logger *log.Logger
+
+	// slowQueryThreshold logs statements that run longer than this; 0 disables it
+	slowQueryThreshold time.Duration
+
+	// maxPriority widens the allowed priority range to 0..maxPriority; 0 keeps the
+	// range stored in the database, or types.DefaultMaxPriority (see WithMaxPriority)
+	maxPriority int
+
+	// encryptionKey and encryptedColumns configure WithColumnEncryption
+	encryptionKey    []byte
+	encryptedColumns []string
+
+	// mirrorPath is a second database file that receives every write (see WithMirror)
+	mirrorPath string
+
+	// skipCommentAutoWatch stops AddComment from adding the commenter as a watcher
+	skipCommentAutoWatch bool
+
+	// notFoundErrors makes GetIssue return ErrIssueNotFound instead of a nil issue
+	notFoundErrors bool
+
+	// defaultSortErr records an invalid WithDefaultSort field or direction for validate
+	defaultSortErr error
+}
+
+// WithAssigneeRequired requires an issue to have an assignee before UpdateIssue
+// moves it into any of the given statuses (e.g. in_progress).
+// Violations return ErrAssigneeRequired.
+func WithAssigneeRequired(statuses ...types.Status) Option {
+	return func(o *options) {
+		if o.assigneeRequired == nil {
+			o.assigneeRequired = make(map[types.Status]bool)
+		}
+		for _, status := range statuses {
+			o.assigneeRequired[status] = true
+		}
+	}
+}
+
+// WithIDPrefix sets the prefix of new issue IDs (e.g. "web" gives "web-1"), overriding
+// the prefix taken from the database filename or the issue_prefix config key. The
+// prefix may only contain letters and underscores, since the number is parsed from
+// whatever follows the dash; a trailing dash is accepted. Numbering continues from
+// the highest existing ID with the same prefix.
+func WithIDPrefix(prefix string) Option {
+	return func(o *options) {
+		o.idPrefix = strings.TrimSuffix(prefix, "-")
+	}
+}
+
+// WithIDChecksum appends a checksum character to generated issue IDs ("bd-123-k"),
+// so ValidateID and ResolveID can catch mistyped references in commit messages and
+// chat. IDs given explicitly at creation are stored as is. Existing IDs keep their
+// form, and numbering continues across both forms.
+func WithIDChecksum() Option {
+	return func(o *options) {
+		o.idChecksum = true
+	}
+}
+
+// WithHooks registers lifecycle hooks for creating, updating and closing issues, so
+// validation plugins and side effects can be added without forking the storage.
+// See Hooks for when each one runs. A later WithHooks replaces an earlier one.
+func WithHooks(hooks Hooks) Option {
+	return func(o *options) {
+		o.hooks = hooks
+	}
+}
+
+// WithUnauditedFields excludes changes to the given fields from the event history:
+// UpdateIssue still writes them, but an update touching only these fields records
+// no event, and a mixed update leaves them out of the event's new value. Use it for
+// volatile bookkeeping fields that would drown out meaningful changes.
+func WithUnauditedFields(fields ...string) Option {
+	return func(o *options) {
+		if o.unauditedFields == nil {
+			o.unauditedFields = make(map[string]bool)
+		}
+		for _, field := range fields {
+			o.unauditedFields[field] = true
+		}
+	}
+}
+
+// WithAutoAssignPool sets the assignees CreateIssueAutoAssign rotates through, in
+// order. It overrides the comma-separated auto_assign.pool config key.
+func WithAutoAssignPool(assignees ...string) Option {
+	return func(o *options) {
+		o.autoAssignPool = assignees
+	}
+}
+
+// WithProjectScope scopes the storage to one project so several projects can share
+// a database. New issues are tagged with the project, GetIssue, SearchIssues and
+// GetReadyWork only see that project's issues, and IDs are numbered per project
+// using the project name as the ID prefix (e.g. "web-1").
+// Related rows (events, labels, dependencies, ...) belong to their issue's project.
+func WithProjectScope(project string) Option {
+	return func(o *options) {
+		o.project = project
+	}
+}
+
+// sortableColumns maps the fields accepted by WithDefaultSort to their issue columns
+var sortableColumns = map[string]string{
+	"id":         "i.id",
+	"title":      "i.title",
+	"status":     "i.status",
+	"priority":   "i.priority",
+	"issue_type": "i.issue_type",
+	"assignee":   "i.assignee",
+	"created_at": "i.created_at",
+	"updated_at": "i.updated_at",
+	"closed_at":  "i.closed_at",
+}
+
+// WithDefaultSort sets the order SearchIssues uses when the filter specifies no sort,
+// replacing the default of priority then newest first. Field must be one of the
+// sortable issue columns (e.g. "title", "updated_at") and dir either "asc" or "desc".
+// Ties are broken by issue ID so the order is stable.
+func WithDefaultSort(field, dir string) Option {
+	return func(o *options) {
+		column, ok := sortableColumns[field]
+		if !ok {
+			o.defaultSortErr = fmt.Errorf("invalid default sort field: %s", field)
+			return
+		}
+		switch strings.ToLower(dir) {
+		case "asc":
+			o.defaultSort = column + " ASC, i.id ASC"
+		case "desc":
+			o.defaultSort = column + " DESC, i.id ASC"
+		default:
+			o.defaultSortErr = fmt.Errorf("invalid default sort direction: %s (must be asc or desc)", dir)
+			return
+		}
+		o.defaultSortErr = nil
+	}
+}
+
+// WithDefaultIssueType sets the type CreateIssue gives issues created without one,
+// saving single-type projects from repeating it on every issue
+func WithDefaultIssueType(issueType types.IssueType) Option {
+	return func(o *options) {
+		o.defaultIssueType = issueType
+	}
+}
+
+// WithTypeDefaultPriorities sets per-type default priorities: an issue created with
+// types.PriorityUnset gets the default for its type, so bugs can default higher than
+// chores. Issues of types without an entry must still be given a priority.
+func WithTypeDefaultPriorities(priorities map[types.IssueType]int) Option {
+	return func(o *options) {
+		o.typePriorities = priorities
+	}
+}
+
+// WithTitleNormalization cleans up issue titles in CreateIssue and UpdateIssue before
+// validation: control characters are removed, runs of whitespace (including tabs and
+// newlines) collapse to a single space, and leading/trailing whitespace is trimmed.
+// This keeps pasted content from producing multi-line or garbled titles.
+func WithTitleNormalization() Option {
+	return func(o *options) {
+		o.normalizeTitles = true
+	}
+}
+
+// WithCheckpointOnClose controls whether Close runs a TRUNCATE WAL checkpoint before
+// closing, so the -wal and -shm files don't linger. Enabled by default.
+func WithCheckpointOnClose(enabled bool) Option {
+	return func(o *options) {
+		o.skipCheckpointOnClose = !enabled
+	}
+}
+
+// WithAutoWatchOnComment controls whether commenting on an issue adds the commenter
+// to its watchers (see AddWatcher). Enabled by default.
+func WithAutoWatchOnComment(enabled bool) Option {
+	return func(o *options) {
+		o.skipCommentAutoWatch = !enabled
+	}
+}
+
+// WithNotFoundErrors makes GetIssue and GetIssueTracked return an error wrapping
+// ErrIssueNotFound for a missing issue, instead of a nil issue and nil error, so
+// callers can handle it with errors.Is like other methods' not-found errors
+func WithNotFoundErrors() Option {
+	return func(o *options) {
+		o.notFoundErrors = true
+	}
+}
+
+// WithClock replaces time.Now as the source of the current time for issue
+// timestamps and age-based reports, so tests and simulations can control time
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.clock = now
+	}
+}
+
+// WithBusyRetry retries the write lock that issue creation takes when another
+// connection holds it (SQLITE_BUSY), with jittered exponential backoff as described
+// by policy, instead of failing on the first busy error. Disabled by default.
+func WithBusyRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.busyRetry = policy
+	}
+}
+
+// WithWIPLimit caps the number of in_progress issues any one assignee may hold.
+// UpdateIssue and ClaimIssue return ErrWIPLimitExceeded rather than move an issue
+// into in_progress for an assignee at the limit. Individual assignees can be given
+// a different limit with SetAssigneeWIPLimit.
+func WithWIPLimit(limit int) Option {
+	return func(o *options) {
+		o.wipLimit = limit
+	}
+}
+
+// WithSLAPausedStatuses pauses SLA clocks while an issue is in any of the given
+// statuses (e.g. blocked while waiting on the reporter), see GetSLAElapsed
+func WithSLAPausedStatuses(statuses ...types.Status) Option {
+	return func(o *options) {
+		if o.slaPaused == nil {
+			o.slaPaused = make(map[types.Status]bool)
+		}
+		for _, status := range statuses {
+			o.slaPaused[status] = true
+		}
+	}
+}
+
+// WithChecklistGatedClose makes CloseIssue, and UpdateIssue setting the status to
+// closed, refuse to close an issue while any of its checklist items are not done.
+// Violations return ErrChecklistIncomplete listing the unfinished items. Issues
+// without a checklist close as usual.
+func WithChecklistGatedClose() Option {
+	return func(o *options) {
+		o.checklistGatedClose = true
+	}
+}
+
+// WithConnMaxIdleTime closes pooled connections that have been idle for longer than d
+func WithConnMaxIdleTime(d time.Duration) Option {
+	return func(o *options) {
+		o.connMaxIdleTime = d
+	}
+}
+
+// WithConnMaxLifetime closes pooled connections once they have been open for longer than d
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.connMaxLifetime = d
+	}
+}
+
+// WithLogger sends the storage's warnings (failed checkpoints, slow queries, ...)
+// to logger instead of stderr
+func WithLogger(logger *log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithSlowQueryThreshold logs a warning with the SQL and its duration for every
+// statement that takes longer than threshold, to help find missing indexes.
+// Statements are timed from execution until their rows are closed.
+// Disabled by default.
+func WithSlowQueryThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.slowQueryThreshold = threshold
+	}
+}
+
+// WithMaxPriority allows priorities from 0 to maxPriority instead of 0-4, for finer
+// granularity. The range is saved in the database's config table, so later opens
+// without the option keep using it.
+func WithMaxPriority(maxPriority int) Option {
+	return func(o *options) {
+		o.maxPriority = maxPriority
+	}
+}
+
+// WithColumnEncryption encrypts the given issue columns (description, design,
+// acceptance_criteria and/or notes) with AES-GCM before they are written, and
+// decrypts them when issues are read. key must be 16, 24 or 32 bytes (AES-128,
+// -192 or -256). Event snapshots store the encrypted values too, so the plaintext
+// never reaches the database.
+//
+// SQL can't see through the encryption: search doesn't match text in encrypted
+// columns, and reports computed in SQL (such as GetThinIssues) see ciphertext.
+// Rows written before encryption was enabled stay readable as plaintext.
+// Opening the database without the key returns the encrypted values as stored.
+func WithColumnEncryption(key []byte, columns ...string) Option {
+	return func(o *options) {
+		o.encryptionKey = key
+		o.encryptedColumns = columns
+	}
+}
+
+// WithMirror replays every write to a second SQLite database at path, giving a warm
+// standby. Writes are mirrored synchronously and inside the same transaction
+// boundaries as the primary, but best-effort: mirror failures are logged as warnings
+// and never fail the primary write. The mirror only sees writes made while it is
+// configured, so it should start out as a copy of the primary (or both empty).
+func WithMirror(path string) Option {
+	return func(o *options) {
+		o.mirrorPath = path
+	}
+}
+
+// warnf logs a warning to the configured logger, or stderr if none
+func (o *options) warnf(format string, args ...interface{}) {
+	if o.logger != nil {
+		o.logger.Printf("warning: "+format, args...)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "warning: "+format+"\n", args...)
+}
+
+// idPrefixPattern keeps WithIDPrefix prefixes free of digits and dashes, which would
+// make the numeric part of an ID ambiguous
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z_]*$`)
+
+// projectNamePattern restricts project names to characters that are safe in an ID prefix
+var projectNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// validate checks that the configured options are consistent
+func (o *options) validate() error {
+	for status := range o.assigneeRequired {
+		if !status.IsValid() {
+			return fmt.Errorf("invalid status for assignee requirement: %s", status)
+		}
+	}
+	for status := range o.slaPaused {
+		if !status.IsValid() {
+			return fmt.Errorf("invalid status for SLA pause: %s", status)
+		}
+	}
+	if o.idPrefix != "" {
+		if !idPrefixPattern.MatchString(o.idPrefix) {
+			return fmt.Errorf("invalid ID prefix %q: must start with a letter and contain only letters and underscores", o.idPrefix)
+		}
+		if o.project != "" {
+			return fmt.Errorf("an ID prefix can't be combined with a project scope, which numbers IDs by project")
+		}
+	}
+	if o.project != "" && !projectNamePattern.MatchString(o.project) {
+		return fmt.Errorf("invalid project name %q: must start with a letter and contain only letters, digits, and underscores", o.project)
+	}
+	for field := range o.unauditedFields {
+		if !allowedUpdateFields[field] {
+			return fmt.Errorf("invalid field for audit exclusion: %s", field)
+		}
+		if field == "status" {
+			return fmt.Errorf("status changes can't be excluded from the audit history")
+		}
+	}
+	for _, assignee := range o.autoAssignPool {
+		if strings.TrimSpace(assignee) == "" {
+			return fmt.Errorf("auto-assign pool must not contain empty assignees")
+		}
+	}
+	if o.defaultIssueType != "" && !o.defaultIssueType.IsValid() {
+		return fmt.Errorf("invalid default issue type: %s", o.defaultIssueType)
+	}
+	for issueType, priority := range o.typePriorities {
+		if !issueType.IsValid() {
+			return fmt.Errorf("invalid issue type for default priority: %s", issueType)
+		}
+		if priority < 0 {
+			return fmt.Errorf("invalid default priority for %s: %d", issueType, priority)
+		}
+	}
+	if o.busyRetry.MaxAttempts < 0 || o.busyRetry.BaseDelay < 0 || o.busyRetry.MaxDelay < 0 {
+		return fmt.Errorf("busy retry attempts and delays must not be negative")
+	}
+	if o.busyRetry.Jitter < 0 || o.busyRetry.Jitter > 1 {
+		return fmt.Errorf("busy retry jitter must be between 0 and 1 (got %v)", o.busyRetry.Jitter)
+	}
+	if o.wipLimit < 0 {
+		return fmt.Errorf("WIP limit must not be negative (got %d)", o.wipLimit)
+	}
+	if o.encryptionKey != nil {
+		switch len(o.encryptionKey) {
+		case 16, 24, 32:
+		default:
+			return fmt.Errorf("encryption key must be 16, 24 or 32 bytes (got %d)", len(o.encryptionKey))
+		}
+		if len(o.encryptedColumns) == 0 {
+			return fmt.Errorf("column encryption needs at least one column")
+		}
+		for _, column := range o.encryptedColumns {
+			if !encryptableColumns[column] {
+				return fmt.Errorf("column %s cannot be encrypted", column)
+			}
+		}
+	}
+	if o.maxPriority < 0 {
+		return fmt.Errorf("max priority must not be negative")
+	}
+	if o.slowQueryThreshold < 0 {
+		return fmt.Errorf("slow query threshold must not be negative")
+	}
+	if o.connMaxIdleTime < 0 || o.connMaxLifetime < 0 {
+		return fmt.Errorf("connection durations must not be negative")
+	}
+	if o.defaultSortErr != nil {
+		return o.defaultSortErr
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// defaultPageSize is the SearchIssuesPage page size when the filter has no Limit
+const defaultPageSize = 50
+
+// cursorVersion is bumped if the cursor's sort key changes, so old tokens are rejected
+const cursorVersion = 1
+
+// ErrInvalidCursor is returned by SearchIssuesPage for a cursor it didn't issue, one
+// that was altered, or one issued for a different query or filter
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageCursor is the sort key of the last issue on a page. CreatedAt is the stored
+// text, not a parsed time, so comparisons match the ORDER BY exactly.
+type pageCursor struct {
+	Version   int    `json:"v"`
+	Pinned    bool   `json:"p"`
+	Priority  int    `json:"pr"`
+	CreatedAt string `json:"c"`
+	ID        string `json:"id"`
+}
+
+// cursorKeyConfigKey is the config table key holding the hex cursor signing key
+const cursorKeyConfigKey = "cursor_key"
+
+// resolveCursorKey returns the database's cursor signing key, generating and saving
+// it on first use. Keeping it in the database lets cursors survive restarts and
+// work across every process sharing the file. When two processes race to create
+// it, INSERT OR IGNORE keeps the first and both read that one back.
+func resolveCursorKey(db *sql.DB) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate cursor key: %w", err)
+	}
+	_, err := db.Exec(`INSERT OR IGNORE INTO config (key, value) VALUES (?, ?)`, cursorKeyConfigKey, hex.EncodeToString(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save cursor key: %w", err)
+	}
+
+	var value string
+	if err := db.QueryRow(`SELECT value FROM config WHERE key = ?`, cursorKeyConfigKey).Scan(&value); err != nil {
+		return nil, fmt.Errorf("failed to read cursor key from config: %w", err)
+	}
+	if key, err = hex.DecodeString(value); err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("invalid cursor key in config")
+	}
+	return key, nil
+}
+
+// cursorMAC signs a cursor payload together with the search it belongs to, so a
+// cursor only resumes the query and filter it was issued for. Limit and Cursor
+// don't change which issues match and are left out.
+func (s *SQLiteStorage) cursorMAC(payload []byte, query string, filter types.IssueFilter) []byte {
+	filter.Limit = 0
+	filter.Cursor = ""
+	scope, _ := json.Marshal(struct {
+		Query  string
+		Filter types.IssueFilter
+	}{query, filter})
+
+	mac := hmac.New(sha256.New, s.cursorKey)
+	mac.Write(payload)
+	mac.Write([]byte{0})
+	mac.Write(scope)
+	return mac.Sum(nil)
+}
+
+// encodeCursor returns the cursor token: the base64 JSON sort key, a dot, and its MAC
+func (s *SQLiteStorage) encodeCursor(c pageCursor, query string, filter types.IssueFilter) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data) + "." +
+		base64.RawURLEncoding.EncodeToString(s.cursorMAC(data, query, filter))
+}
+
+// decodeCursor verifies a token from encodeCursor against the search it is resuming
+func (s *SQLiteStorage) decodeCursor(token, query string, filter types.IssueFilter) (pageCursor, error) {
+	var c pageCursor
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return c, ErrInvalidCursor
+	}
+	data, err := base64.RawURLEncoding.Strict().DecodeString(payload)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.Strict().DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.cursorMAC(data, query, filter)) {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	if c.Version != cursorVersion || c.ID == "" || c.CreatedAt == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// SearchIssuesPage is SearchIssues with keyset pagination. It returns up to
+// filter.Limit issues (50 if unset) and, unless this is the last page, a NextCursor
+// to pass back in filter.Cursor for the next page. Unlike OFFSET paging, resuming
+// from a cursor costs the same on every page and doesn't skip or repeat issues
+// when others are inserted in between. Cursors are signed with a key kept in the
+// database, so they stay valid across restarts and processes, and only resume the
+// same query and filter; anything else returns ErrInvalidCursor.
+//
+// Pages always use the default order (pinned first, then priority, newest first);
+// filter.SortBy and WithDefaultSort are not supported.
+func (s *SQLiteStorage) SearchIssuesPage(ctx context.Context, query string, filter types.IssueFilter) (*types.IssuePage, error) {
+	if filter.SortBy != "" {
+		return nil, fmt.Errorf("sort %q is not supported with pagination", filter.SortBy)
+	}
+	pageSize := filter.Limit
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	whereSQL, args := s.buildIssueWhere(query, filter)
+	if filter.Cursor != "" {
+		c, err := s.decodeCursor(filter.Cursor, query, filter)
+		if err != nil {
+			return nil, err
+		}
+		keyset := `(i.pinned < ? OR (i.pinned = ? AND (i.priority > ? OR (i.priority = ? AND
+			(i.created_at < ? OR (i.created_at = ? AND i.id > ?))))))`
+		if whereSQL == "" {
+			whereSQL = "WHERE " + keyset
+		} else {
+			whereSQL += " AND " + keyset
+		}
+		args = append(args, c.Pinned, c.Pinned, c.Priority, c.Priority, c.CreatedAt, c.CreatedAt, c.ID)
+	}
+
+	// Fetch one extra row to learn whether there is a next page
+	args = append(args, pageSize+1)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`, CAST(i.created_at AS TEXT)
+		FROM issues i
+		`+whereSQL+`
+		ORDER BY i.pinned DESC, i.priority ASC, i.created_at DESC, i.id ASC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	page := &types.IssuePage{}
+	var last pageCursor
+	for rows.Next() {
+		var createdAt string
+		issue, err := s.scanIssue(rows, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		if len(page.Issues) == pageSize {
+			page.NextCursor = s.encodeCursor(last, query, filter)
+			break
+		}
+		page.Issues = append(page.Issues, issue)
+		last = pageCursor{
+			Version:   cursorVersion,
+			Pinned:    issue.Pinned,
+			Priority:  issue.Priority,
+			CreatedAt: createdAt,
+			ID:        issue.ID,
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating issues: %w", err)
+	}
+
+	return page, nil
+}
+
+// ListIssues pages through the issues matching filter, pageSize at a time, for
+// infinite scrolling. Pass an empty cursor for the first page and the returned
+// nextCursor for each following one; an empty nextCursor means there are no more
+// pages. It is SearchIssuesPage with the cursor and page size as arguments: the
+// cursor holds the last issue's (pinned, priority, created_at, id) sort key, so
+// issues inserted while paging don't shift later pages. filter.Limit and
+// filter.Cursor are ignored.
+func (s *SQLiteStorage) ListIssues(ctx context.Context, filter types.IssueFilter, cursor string, pageSize int) (issues []*types.Issue, nextCursor string, err error) {
+	filter.Cursor = cursor
+	filter.Limit = pageSize
+	page, err := s.SearchIssuesPage(ctx, "", filter)
+	if err != nil {
+		return nil, "", err
+	}
+	return page.Issues, page.NextCursor, nil
+}
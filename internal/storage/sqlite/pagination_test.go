@@ -0,0 +1,280 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestSearchIssuesPage(t *testing.T) {
+	now := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+	store := setupTestDB(t, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	// Many issues share a priority and creation time, so the id tiebreak matters
+	const total = 137
+	want := make(map[string]bool, total)
+	for n := 0; n < total; n++ {
+		if n%10 == 0 {
+			now = now.Add(time.Minute)
+		}
+		issue := &types.Issue{Title: "Paged", Status: types.StatusOpen, Priority: n % 3, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		want[issue.ID] = true
+	}
+
+	seen := make(map[string]bool, total)
+	var order []string
+	filter := types.IssueFilter{Limit: 20}
+	pages := 0
+	for {
+		page, err := store.SearchIssuesPage(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssuesPage failed: %v", err)
+		}
+		pages++
+		for _, issue := range page.Issues {
+			if seen[issue.ID] {
+				t.Fatalf("Issue %s returned twice", issue.ID)
+			}
+			seen[issue.ID] = true
+			order = append(order, issue.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		// Inserting between pages must not shift the remaining pages
+		if pages == 2 {
+			extra := &types.Issue{Title: "Inserted", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+			if err := store.CreateIssue(ctx, extra, "test"); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	if pages != 7 {
+		t.Errorf("Expected 7 pages of up to 20, got %d", pages)
+	}
+	for id := range want {
+		if !seen[id] {
+			t.Errorf("Issue %s was skipped", id)
+		}
+	}
+
+	// Pages concatenate to the same order as a single unpaged search
+	all, err := store.SearchIssuesPage(ctx, "", types.IssueFilter{Limit: 1000})
+	if err != nil {
+		t.Fatalf("SearchIssuesPage failed: %v", err)
+	}
+	var unpaged []string
+	for _, issue := range all.Issues {
+		if want[issue.ID] {
+			unpaged = append(unpaged, issue.ID)
+		}
+	}
+	for i := range unpaged {
+		if order[i] != unpaged[i] {
+			t.Fatalf("Paged order diverges at %d: %s vs %s", i, order[i], unpaged[i])
+		}
+	}
+
+	for _, bad := range []string{"not-base64!", "eyJ2IjoxfQ", "bm90IGpzb24"} {
+		if _, err := store.SearchIssuesPage(ctx, "", types.IssueFilter{Cursor: bad}); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestListIssues(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	open := types.StatusOpen
+	var want []string
+	for n := 0; n < 7; n++ {
+		issue := &types.Issue{Title: "Listed", Status: types.StatusOpen, Priority: n % 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		want = append(want, issue.ID)
+	}
+	closed := &types.Issue{Title: "Done", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, closed, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, closed.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	var got []string
+	cursor := ""
+	for pages := 1; ; pages++ {
+		issues, next, err := store.ListIssues(ctx, types.IssueFilter{Status: &open}, cursor, 3)
+		if err != nil {
+			t.Fatalf("ListIssues failed: %v", err)
+		}
+		if len(issues) > 3 {
+			t.Fatalf("Page %d has %d issues, want at most 3", pages, len(issues))
+		}
+		for _, issue := range issues {
+			got = append(got, issue.ID)
+		}
+		if next == "" {
+			if pages != 3 {
+				t.Errorf("Expected 3 pages, got %d", pages)
+			}
+			break
+		}
+		cursor = next
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d open issues, got %v", len(want), got)
+	}
+	seen := make(map[string]bool)
+	for _, id := range got {
+		if seen[id] || id == closed.ID {
+			t.Errorf("Unexpected or repeated issue %s", id)
+		}
+		seen[id] = true
+	}
+
+	if _, _, err := store.ListIssues(ctx, types.IssueFilter{}, "bogus", 3); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestListIssuesCursorSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "vc.db")
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	for n := 0; n < 4; n++ {
+		issue := &types.Issue{Title: "Scrolled", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	open := types.StatusOpen
+	filter := types.IssueFilter{Status: &open}
+	page, next, err := store.ListIssues(ctx, filter, "", 2)
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(page) != 2 || next == "" {
+		t.Fatalf("Expected a first page of 2 with a next cursor, got %d (next %q)", len(page), next)
+	}
+	_ = store.Close()
+
+	// The client keeps scrolling after the server restarts
+	store, err = New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	page, next, err = store.ListIssues(ctx, filter, next, 2)
+	if err != nil {
+		t.Fatalf("Expected the cursor to survive a restart, got %v", err)
+	}
+	if len(page) != 2 || next != "" {
+		t.Errorf("Expected the last page of 2, got %d (next %q)", len(page), next)
+	}
+}
+
+func TestSearchIssuesPageCursorTampering(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	for n := 0; n < 5; n++ {
+		issue := &types.Issue{Title: "Paged", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	open := types.StatusOpen
+	filter := types.IssueFilter{Status: &open, Limit: 2}
+	page, err := store.SearchIssuesPage(ctx, "Paged", filter)
+	if err != nil {
+		t.Fatalf("SearchIssuesPage failed: %v", err)
+	}
+	if page.NextCursor == "" {
+		t.Fatal("Expected a next cursor")
+	}
+
+	// The untouched cursor resumes the search, even with a different page size
+	filter.Cursor = page.NextCursor
+	filter.Limit = 10
+	if _, err := store.SearchIssuesPage(ctx, "Paged", filter); err != nil {
+		t.Fatalf("Expected the issued cursor to be accepted, got %v", err)
+	}
+
+	// Flipping any byte of the token invalidates it
+	for i := 0; i < len(page.NextCursor); i++ {
+		tampered := []byte(page.NextCursor)
+		tampered[i] ^= 0x01
+		filter.Cursor = string(tampered)
+		if _, err := store.SearchIssuesPage(ctx, "Paged", filter); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("Expected ErrInvalidCursor with byte %d flipped, got %v", i, err)
+		}
+	}
+
+	// A cursor only resumes the query and filter it was issued for
+	filter.Cursor = page.NextCursor
+	if _, err := store.SearchIssuesPage(ctx, "Other", filter); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a different query, got %v", err)
+	}
+	if _, err := store.SearchIssuesPage(ctx, "Paged", types.IssueFilter{Cursor: page.NextCursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a different filter, got %v", err)
+	}
+
+	// Another database doesn't accept this one's cursors
+	other := setupTestDB(t)
+	if _, err := other.SearchIssuesPage(ctx, "Paged", filter); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor from another database, got %v", err)
+	}
+}
+
+func TestSearchIssuesPageCursorSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "vc.db")
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	for n := 0; n < 5; n++ {
+		issue := &types.Issue{Title: "Paged", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	first, err := store.SearchIssuesPage(ctx, "", types.IssueFilter{Limit: 3})
+	if err != nil {
+		t.Fatalf("SearchIssuesPage failed: %v", err)
+	}
+	_ = store.Close()
+
+	// A restarted process, and a second one on the same file, both resume the cursor
+	for i := 0; i < 2; i++ {
+		reopened, err := New(path)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer func() { _ = reopened.Close() }()
+		rest, err := reopened.SearchIssuesPage(ctx, "", types.IssueFilter{Limit: 3, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatalf("Expected the cursor to survive reopening, got %v", err)
+		}
+		if len(rest.Issues) != 2 || rest.NextCursor != "" {
+			t.Errorf("Expected the last 2 issues, got %d (next %q)", len(rest.Issues), rest.NextCursor)
+		}
+	}
+}
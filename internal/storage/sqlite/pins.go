@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+)
+
+// PinIssue pins an issue so SearchIssues lists it ahead of unpinned issues
+func (s *SQLiteStorage) PinIssue(ctx context.Context, id string) error {
+	return s.setPinned(ctx, id, true)
+}
+
+// UnpinIssue removes an issue's pin
+func (s *SQLiteStorage) UnpinIssue(ctx context.Context, id string) error {
+	return s.setPinned(ctx, id, false)
+}
+
+func (s *SQLiteStorage) setPinned(ctx context.Context, id string, pinned bool) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE issues SET pinned = ?, updated_at = ?
+		WHERE id = ? AND (? = '' OR project_id = ?)
+	`, pinned, s.now(), id, s.opts.project, s.opts.project)
+	if err != nil {
+		return fmt.Errorf("failed to update pinned state: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestPinnedIssuesSortFirst(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, priority := range []int{0, 1, 4} {
+		issue := &types.Issue{Title: "Pin test", Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	backlog := issues[2]
+
+	if err := store.PinIssue(ctx, backlog.ID); err != nil {
+		t.Fatalf("PinIssue failed: %v", err)
+	}
+
+	for _, filter := range []types.IssueFilter{{}, {SortBy: types.SortByVotes}} {
+		results, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		if len(results) != 3 || results[0].ID != backlog.ID || !results[0].Pinned {
+			t.Errorf("Expected pinned backlog issue first with sort %q, got %s", filter.SortBy, results[0].ID)
+		}
+		if results[1].ID != issues[0].ID {
+			t.Errorf("Expected unpinned issues in priority order after the pin, got %s", results[1].ID)
+		}
+	}
+
+	if err := store.UnpinIssue(ctx, backlog.ID); err != nil {
+		t.Fatalf("UnpinIssue failed: %v", err)
+	}
+	results, _ := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if results[2].ID != backlog.ID || results[2].Pinned {
+		t.Errorf("Expected unpinned backlog issue last, got %s", results[2].ID)
+	}
+
+	if err := store.PinIssue(ctx, "vc-9999"); err == nil {
+		t.Error("Expected error pinning a non-existent issue")
+	}
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// maxPriorityConfigKey is the config table key holding the range set by WithMaxPriority
+const maxPriorityConfigKey = "max_priority"
+
+// resolveMaxPriority returns the highest allowed priority. A configured value is
+// saved so it survives reopening; otherwise the saved value, or the default, is used.
+func resolveMaxPriority(db *sql.DB, configured int) (int, error) {
+	if configured > 0 {
+		_, err := db.Exec(`
+			INSERT INTO config (key, value) VALUES (?, ?)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value
+		`, maxPriorityConfigKey, strconv.Itoa(configured))
+		if err != nil {
+			return 0, fmt.Errorf("failed to save max priority: %w", err)
+		}
+		return configured, nil
+	}
+
+	var value string
+	err := db.QueryRow(`SELECT value FROM config WHERE key = ?`, maxPriorityConfigKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return types.DefaultMaxPriority, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read max priority from config: %w", err)
+	}
+	maxPriority, err := strconv.Atoi(value)
+	if err != nil || maxPriority < 0 {
+		return 0, fmt.Errorf("invalid max priority in config: %q", value)
+	}
+	return maxPriority, nil
+}
@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestWithMaxPriority(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vc.db")
+	ctx := context.Background()
+
+	store, err := New(path, WithMaxPriority(10))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	issue := &types.Issue{Title: "Fine grained", Status: types.StatusOpen, Priority: 7, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Expected priority 7 to be accepted, got %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 11}, "test"); !errors.Is(err, types.ErrInvalidPriority) {
+		t.Errorf("Expected ErrInvalidPriority for 11, got %v", err)
+	}
+	_ = store.Close()
+
+	// The range survives reopening without the option
+	store, err = New(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 9}, "test"); err != nil {
+		t.Errorf("Expected priority 9 to be accepted after reopen, got %v", err)
+	}
+
+	// Stores without the option keep the default range
+	defaultStore := setupTestDB(t)
+	other := &types.Issue{Title: "Default range", Status: types.StatusOpen, Priority: 7, IssueType: types.TypeTask}
+	if err := defaultStore.CreateIssue(ctx, other, "test"); !errors.Is(err, types.ErrInvalidPriority) {
+		t.Errorf("Expected ErrInvalidPriority for 7 by default, got %v", err)
+	}
+}
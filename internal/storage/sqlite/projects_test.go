@@ -0,0 +1,227 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestProjectScopeIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	tmpfile, err := os.CreateTemp("", "test-*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpfile.Name()) }()
+	_ = tmpfile.Close()
+
+	open := func(opts ...Option) *SQLiteStorage {
+		t.Helper()
+		store, err := New(tmpfile.Name(), opts...)
+		if err != nil {
+			t.Fatalf("Failed to open storage: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	}
+
+	storeA := open(WithProjectScope("alpha"))
+	storeB := open(WithProjectScope("beta"))
+	unscoped := open()
+
+	issueA := &types.Issue{Title: "Alpha work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := storeA.CreateIssue(ctx, issueA, "test"); err != nil {
+		t.Fatalf("Failed to create issue in alpha: %v", err)
+	}
+	issueB := &types.Issue{Title: "Beta work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := storeB.CreateIssue(ctx, issueB, "test"); err != nil {
+		t.Fatalf("Failed to create issue in beta: %v", err)
+	}
+
+	// Each project has its own ID sequence
+	if issueA.ID != "alpha-1" || issueB.ID != "beta-1" {
+		t.Errorf("Expected per-project IDs alpha-1 and beta-1, got %s and %s", issueA.ID, issueB.ID)
+	}
+
+	// An issue in project A is invisible when scoped to project B
+	got, err := storeB.GetIssue(ctx, issueA.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Expected %s to be invisible from beta, got %+v", issueA.ID, got)
+	}
+	if err := storeB.UpdateIssue(ctx, issueA.ID, map[string]interface{}{"priority": 0}, "test"); err == nil {
+		t.Error("Expected UpdateIssue across projects to fail")
+	}
+
+	results, err := storeB.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issueB.ID {
+		t.Errorf("Expected beta search to return only %s, got %d results", issueB.ID, len(results))
+	}
+
+	ready, err := storeA.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != issueA.ID {
+		t.Errorf("Expected alpha ready work to contain only %s, got %d issues", issueA.ID, len(ready))
+	}
+
+	// Unscoped storage sees every project
+	all, err := unscoped.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Unscoped SearchIssues failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected unscoped search to see 2 issues, got %d", len(all))
+	}
+	got, _ = unscoped.GetIssue(ctx, issueA.ID)
+	if got == nil || got.ProjectID != "alpha" {
+		t.Errorf("Expected %s to be tagged with project alpha, got %+v", issueA.ID, got)
+	}
+}
+
+func TestProjectScopeValidation(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test-*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpfile.Name()) }()
+	_ = tmpfile.Close()
+
+	for _, name := range []string{"has-dash", "1numeric", "spa ce"} {
+		if _, err := New(tmpfile.Name(), WithProjectScope(name)); err == nil {
+			t.Errorf("Expected project name %q to be rejected", name)
+		}
+	}
+}
+
+func TestProjectScopeCrossProjectWrites(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "vc.db")
+	open := func(opts ...Option) *SQLiteStorage {
+		t.Helper()
+		store, err := New(path, opts...)
+		if err != nil {
+			t.Fatalf("Failed to open storage: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	}
+	web := open(WithProjectScope("web"))
+	api := open(WithProjectScope("api"))
+
+	// A thin issue (no description) in each project
+	apiIssue := &types.Issue{Title: "API work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := api.CreateIssue(ctx, apiIssue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	webIssue := &types.Issue{Title: "Web work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := web.CreateIssue(ctx, webIssue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := web.CloseIssue(ctx, apiIssue.ID, "done", "test"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound closing another project's issue, got %v", err)
+	}
+	got, err := api.GetIssue(ctx, apiIssue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen {
+		t.Errorf("Expected %s to stay open, got %s", apiIssue.ID, got.Status)
+	}
+
+	if err := web.AddLabel(ctx, apiIssue.ID, "frontend", "test"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound labeling another project's issue, got %v", err)
+	}
+	if events, err := web.GetEvents(ctx, apiIssue.ID, 0); err != nil || len(events) != 0 {
+		t.Errorf("Expected no events for another project's issue, got %d (%v)", len(events), err)
+	}
+
+	thin, err := web.GetThinIssues(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetThinIssues failed: %v", err)
+	}
+	if len(thin) != 1 || thin[0].ID != webIssue.ID {
+		t.Errorf("Expected the report to list only %s, got %v", webIssue.ID, issueIDs(thin))
+	}
+
+	// Votes, comments, watchers, reminders and aliases are scoped the same way
+	remindAt := time.Now().Add(-time.Hour)
+	if _, err := api.AddReminder(ctx, apiIssue.ID, remindAt, "ping", "alice"); err != nil {
+		t.Fatalf("AddReminder failed: %v", err)
+	}
+	commentID, err := api.AddCommentWithID(ctx, apiIssue.ID, "alice", "API only")
+	if err != nil {
+		t.Fatalf("AddCommentWithID failed: %v", err)
+	}
+	for name, run := range map[string]func() error{
+		"Vote":   func() error { return web.Vote(ctx, apiIssue.ID, "bob") },
+		"Unvote": func() error { return web.Unvote(ctx, apiIssue.ID, "bob") },
+		"GetVoteCount": func() error {
+			_, err := web.GetVoteCount(ctx, apiIssue.ID)
+			return err
+		},
+		"AddReminder": func() error {
+			_, err := web.AddReminder(ctx, apiIssue.ID, remindAt, "", "bob")
+			return err
+		},
+		"AddComment": func() error { return web.AddComment(ctx, apiIssue.ID, "bob", "hi") },
+		"GetComments": func() error {
+			_, err := web.GetComments(ctx, apiIssue.ID)
+			return err
+		},
+		"RemoveWatcher": func() error { return web.RemoveWatcher(ctx, apiIssue.ID, "alice") },
+		"GetWatchers": func() error {
+			_, err := web.GetWatchers(ctx, apiIssue.ID)
+			return err
+		},
+		"GetIDAliases": func() error {
+			_, err := web.GetIDAliases(ctx, apiIssue.ID)
+			return err
+		},
+	} {
+		if err := run(); !errors.Is(err, ErrIssueNotFound) {
+			t.Errorf("Expected ErrIssueNotFound from %s on another project's issue, got %v", name, err)
+		}
+	}
+	if err := web.EditComment(ctx, commentID, "edited", "bob"); err == nil {
+		t.Error("Expected error editing another project's comment")
+	}
+	if comments, _ := api.GetComments(ctx, apiIssue.ID); len(comments) != 1 || comments[0].Body != "API only" {
+		t.Errorf("Expected the comment to be untouched, got %+v", comments)
+	}
+
+	due, err := web.GetDueReminders(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("GetDueReminders failed: %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("Expected no due reminders from another project, got %d", len(due))
+	}
+	if due, _ := api.GetDueReminders(ctx, time.Time{}); len(due) != 1 {
+		t.Errorf("Expected the project's own reminder to be due, got %d", len(due))
+	}
+
+	mission, err := web.GetMission(ctx, apiIssue.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if mission != nil {
+		t.Errorf("Expected no mission from another project, got %s", mission.ID)
+	}
+	if mission, _ := api.GetMission(ctx, apiIssue.ID); mission == nil {
+		t.Error("Expected the project's own mission")
+	}
+}
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// timedQuery runs a query whose duration is checked against the slow query
+// threshold. SQLite does most of a query's work while rows are read, so the
+// query is timed until its rows are closed.
+func (c *conn) timedQuery(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.SQLiteConn.QueryContext(ctx, query, args)
+	if err != nil {
+		c.checkSlow(query, time.Since(start))
+		return nil, err
+	}
+	sqliteRows, ok := rows.(*sqlite3.SQLiteRows)
+	if !ok {
+		c.checkSlow(query, time.Since(start))
+		return rows, nil
+	}
+	return &timedRows{SQLiteRows: sqliteRows, conn: c, query: query, start: start}, nil
+}
+
+// checkSlow logs query if it ran longer than the slow query threshold
+func (c *conn) checkSlow(query string, elapsed time.Duration) {
+	if c.opts.slowQueryThreshold > 0 && elapsed > c.opts.slowQueryThreshold {
+		c.opts.warnf("slow query (%v): %s", elapsed, strings.Join(strings.Fields(query), " "))
+	}
+}
+
+type timedRows struct {
+	*sqlite3.SQLiteRows
+	conn  *conn
+	query string
+	start time.Time
+}
+
+func (r *timedRows) Close() error {
+	err := r.SQLiteRows.Close()
+	r.conn.checkSlow(r.query, time.Since(r.start))
+	return err
+}
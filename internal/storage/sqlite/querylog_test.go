@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestSlowQueryThreshold(t *testing.T) {
+	var buf bytes.Buffer
+	store := setupTestDB(t, WithSlowQueryThreshold(time.Millisecond), WithLogger(log.New(&buf, "", 0)))
+	ctx := context.Background()
+
+	var count int
+	err := store.db.QueryRowContext(ctx, `
+		WITH RECURSIVE counter(n) AS (
+			SELECT 1 UNION ALL SELECT n + 1 FROM counter WHERE n < 500000
+		)
+		SELECT COUNT(*) FROM counter
+	`).Scan(&count)
+	if err != nil {
+		t.Fatalf("Slow query failed: %v", err)
+	}
+	if count != 500000 {
+		t.Fatalf("Expected 500000, got %d", count)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, "slow query") || !strings.Contains(logged, "WITH RECURSIVE counter(n) AS") {
+		t.Errorf("Expected a slow query warning with the SQL, got %q", logged)
+	}
+
+	// Regular operations keep working through the timed connections
+	issue := &types.Issue{Title: "Timed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if got, err := store.GetIssue(ctx, issue.ID); err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	var label string
+	if err := store.db.QueryRowContext(ctx, `SELECT priority_label(2)`).Scan(&label); err != nil || label != "medium" {
+		t.Errorf("Expected helper functions to be registered, got %q, %v", label, err)
+	}
+}
+
+func TestSlowQueryThresholdDisabledByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	store := setupTestDB(t, WithLogger(log.New(&buf, "", 0)))
+
+	var count int
+	err := store.db.QueryRow(`
+		WITH RECURSIVE counter(n) AS (
+			SELECT 1 UNION ALL SELECT n + 1 FROM counter WHERE n < 100000
+		)
+		SELECT COUNT(*) FROM counter
+	`).Scan(&count)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no warnings without a threshold, got %q", buf.String())
+	}
+}
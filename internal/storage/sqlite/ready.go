@@ -2,7 +2,6 @@ package sqlite
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"strings"
 
@@ -27,6 +26,14 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	whereClauses = append(whereClauses, "i.issue_type != ?")
 	args = append(args, "epic")
 
+	// Archived issues are hidden from work queues as from searches
+	whereClauses = append(whereClauses, "i.archived_at IS NULL")
+
+	if s.opts.project != "" {
+		whereClauses = append(whereClauses, "i.project_id = ?")
+		args = append(args, s.opts.project)
+	}
+
 	if filter.Priority != nil {
 		whereClauses = append(whereClauses, "i.priority = ?")
 		args = append(args, *filter.Priority)
@@ -49,9 +56,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 
 	// Single query template
 	query := fmt.Sprintf(`
-		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
-		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at
+		SELECT `+issueColumns+`
 		FROM issues i
 		WHERE %s
 		  AND NOT EXISTS (
@@ -71,17 +76,14 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	}
 	defer func() { _ = rows.Close() }()
 
-	return scanIssues(rows)
+	return s.scanIssues(rows)
 }
 
 // GetBlockedIssues returns issues that are blocked by dependencies
 func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
 	// Use GROUP_CONCAT to get all blocker IDs in a single query (no N+1)
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT
-		    i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
-		    i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		    i.created_at, i.updated_at, i.closed_at,
+		SELECT `+issueColumns+`,
 		    COUNT(d.depends_on_id) as blocked_by_count,
 		    GROUP_CONCAT(d.depends_on_id, ',') as blocker_ids
 		FROM issues i
@@ -100,40 +102,25 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 
 	var blocked []*types.BlockedIssue
 	for rows.Next() {
-		var issue types.BlockedIssue
-		var closedAt sql.NullTime
-		var estimatedMinutes sql.NullInt64
-		var assignee sql.NullString
+		var blockedByCount int
 		var blockerIDsStr string
 
-		err := rows.Scan(
-			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
-			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &issue.BlockedByCount,
-			&blockerIDsStr,
-		)
+		issue, err := s.scanIssue(rows, &blockedByCount, &blockerIDsStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
 		}
 
-		if closedAt.Valid {
-			issue.ClosedAt = &closedAt.Time
-		}
-		if estimatedMinutes.Valid {
-			mins := int(estimatedMinutes.Int64)
-			issue.EstimatedMinutes = &mins
-		}
-		if assignee.Valid {
-			issue.Assignee = assignee.String
+		blockedIssue := &types.BlockedIssue{
+			Issue:          *issue,
+			BlockedByCount: blockedByCount,
 		}
 
 		// Parse comma-separated blocker IDs
 		if blockerIDsStr != "" {
-			issue.BlockedBy = strings.Split(blockerIDsStr, ",")
+			blockedIssue.BlockedBy = strings.Split(blockerIDsStr, ",")
 		}
 
-		blocked = append(blocked, &issue)
+		blocked = append(blocked, blockedIssue)
 	}
 
 	return blocked, nil
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// RecomputePriorities applies rule across the backlog in one transaction: every
+// open issue matching the rule's conditions whose priority differs from the rule's
+// gets it, with an EventUpdated recording the change. Returns how many issues changed.
+func (s *SQLiteStorage) RecomputePriorities(ctx context.Context, rule types.PriorityRule, actor string) (int, error) {
+	if err := types.ValidatePriority(rule.Priority, s.maxPriority); err != nil {
+		return 0, err
+	}
+	if rule.OlderThan < 0 {
+		return 0, fmt.Errorf("older than cannot be negative")
+	}
+
+	whereClauses := []string{"status != ?", "priority != ?"}
+	args := []interface{}{types.StatusClosed, rule.Priority}
+	if s.opts.project != "" {
+		whereClauses = append(whereClauses, "project_id = ?")
+		args = append(args, s.opts.project)
+	}
+	if rule.OlderThan > 0 {
+		whereClauses = append(whereClauses, "julianday(created_at) <= julianday(?)")
+		args = append(args, s.now().Add(-rule.OlderThan))
+	}
+	if rule.Unassigned {
+		whereClauses = append(whereClauses, "(assignee IS NULL OR assignee = '')")
+	}
+	if len(rule.Statuses) > 0 {
+		placeholders := make([]string, len(rule.Statuses))
+		for i, status := range rule.Statuses {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if rule.IssueType != "" {
+		whereClauses = append(whereClauses, "issue_type = ?")
+		args = append(args, rule.IssueType)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, priority FROM issues
+		WHERE `+strings.Join(whereClauses, " AND ")+`
+		ORDER BY id
+	`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find matching issues: %w", err)
+	}
+	current := make(map[string]int)
+	var ids []string
+	for rows.Next() {
+		var id string
+		var priority int
+		if err := rows.Scan(&id, &priority); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		ids = append(ids, id)
+		current[id] = priority
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating issues: %w", err)
+	}
+
+	newData, err := json.Marshal(map[string]interface{}{"priority": rule.Priority})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	now := s.now()
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, `UPDATE issues SET priority = ?, updated_at = ? WHERE id = ?`, rule.Priority, now, id); err != nil {
+			return 0, fmt.Errorf("failed to update priority of %s: %w", id, err)
+		}
+		oldData, err := json.Marshal(map[string]interface{}{"priority": current[id]})
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, id, types.EventUpdated, actor, string(oldData), string(newData), "Recomputed by priority rule", now)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit priority changes: %w", err)
+	}
+	return len(ids), nil
+}
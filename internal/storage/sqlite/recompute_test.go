@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestRecomputePriorities(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	create := func(title, assignee string, status types.Status, age time.Duration) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Assignee: assignee, Status: status, Priority: 3, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if _, err := store.db.Exec(`UPDATE issues SET created_at = ? WHERE id = ?`, time.Now().Add(-age), issue.ID); err != nil {
+			t.Fatalf("Failed to age issue: %v", err)
+		}
+		return issue
+	}
+
+	month := 30 * 24 * time.Hour
+	stale := create("Stale and unowned", "", types.StatusOpen, 45*24*time.Hour)
+	owned := create("Stale but owned", "alice", types.StatusOpen, 45*24*time.Hour)
+	fresh := create("Fresh", "", types.StatusOpen, time.Hour)
+	closed := create("Stale but closed", "", types.StatusClosed, 45*24*time.Hour)
+
+	rule := types.PriorityRule{OlderThan: month, Unassigned: true, Priority: 1}
+	changed, err := store.RecomputePriorities(ctx, rule, "triage-bot")
+	if err != nil {
+		t.Fatalf("RecomputePriorities failed: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("Expected 1 issue changed, got %d", changed)
+	}
+
+	for issue, want := range map[*types.Issue]int{stale: 1, owned: 3, fresh: 3, closed: 3} {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Priority != want {
+			t.Errorf("Expected %q to have priority %d, got %d", issue.Title, want, got.Priority)
+		}
+	}
+
+	events, err := store.GetEvents(ctx, stale.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	recorded := false
+	for _, event := range events {
+		recorded = recorded || (event.EventType == types.EventUpdated && event.Actor == "triage-bot")
+	}
+	if !recorded {
+		t.Error("Expected an update event by triage-bot")
+	}
+
+	// Applying the rule again changes nothing
+	changed, err = store.RecomputePriorities(ctx, rule, "triage-bot")
+	if err != nil {
+		t.Fatalf("RecomputePriorities failed: %v", err)
+	}
+	if changed != 0 {
+		t.Errorf("Expected a second run to change nothing, got %d", changed)
+	}
+
+	if _, err := store.RecomputePriorities(ctx, types.PriorityRule{Priority: 9}, "triage-bot"); err == nil {
+		t.Error("Expected an error for an out-of-range priority")
+	}
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// FindReferences returns the other issues whose title, description, design,
+// acceptance criteria or notes mention id, by priority then age. Matches must stand
+// alone as a word, so "bd-5" doesn't match "bd-55" or "xbd-5". This surfaces
+// informal cross-references that aren't recorded as dependencies.
+func (s *SQLiteStorage) FindReferences(ctx context.Context, id string) ([]*types.Issue, error) {
+	if id == "" {
+		return nil, fmt.Errorf("issue ID is required")
+	}
+	mention := regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(id) + `($|[^A-Za-z0-9_])`)
+
+	// LIKE narrows the candidates cheaply; encrypted columns can only be checked
+	// once decrypted, so then every issue is a candidate
+	textFilter := `AND (i.title LIKE ? OR i.description LIKE ? OR i.design LIKE ?
+		     OR i.acceptance_criteria LIKE ? OR i.notes LIKE ?)`
+	pattern := "%" + id + "%"
+	args := []interface{}{id, s.opts.project, s.opts.project}
+	if s.cipher != nil {
+		textFilter = ""
+	} else {
+		args = append(args, pattern, pattern, pattern, pattern, pattern)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE i.id != ? AND (? = '' OR i.project_id = ?)
+		`+textFilter+`
+		ORDER BY i.priority ASC, i.created_at ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find references: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	candidates, err := s.scanIssues(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []*types.Issue
+	for _, issue := range candidates {
+		for _, text := range []string{issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes} {
+			if mention.MatchString(text) {
+				references = append(references, issue)
+				break
+			}
+		}
+	}
+	return references, nil
+}
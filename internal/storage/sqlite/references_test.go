@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestFindReferences(t *testing.T) {
+	store := setupTestDB(t, WithIDPrefix("bd"))
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		issue := &types.Issue{Title: "Filler", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	mentions := &types.Issue{Title: "Follow-up", Description: "Regression from bd-5, see notes.", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	inNotes := &types.Issue{Title: "Cleanup", Notes: "(bd-5)", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeChore}
+	lookalike := &types.Issue{Title: "Unrelated", Description: "Caused by bd-55 and abd-5", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	for _, issue := range []*types.Issue{mentions, inNotes, lookalike} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	refs, err := store.FindReferences(ctx, "bd-5")
+	if err != nil {
+		t.Fatalf("FindReferences failed: %v", err)
+	}
+	if len(refs) != 2 || refs[0].ID != mentions.ID || refs[1].ID != inNotes.ID {
+		ids := make([]string, len(refs))
+		for i, ref := range refs {
+			ids[i] = ref.ID
+		}
+		t.Errorf("Expected %s and %s, got %v", mentions.ID, inNotes.ID, ids)
+	}
+
+	refs, err = store.FindReferences(ctx, "bd-1")
+	if err != nil {
+		t.Fatalf("FindReferences failed: %v", err)
+	}
+	if len(refs) != 0 {
+		t.Errorf("Expected no references to bd-1, got %d", len(refs))
+	}
+}
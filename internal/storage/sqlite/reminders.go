@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// AddReminder schedules a reminder about an issue and returns its ID. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) AddReminder(ctx context.Context, issueID string, remindAt time.Time, note, recipient string) (int64, error) {
+	if strings.TrimSpace(recipient) == "" {
+		return 0, types.NewValidationError("recipient", recipient, types.ErrInvalidField, "recipient is required")
+	}
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO reminders (issue_id, remind_at, note, recipient)
+		VALUES (?, ?, ?, ?)
+	`, issueID, remindAt.UTC(), note, recipient)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add reminder: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reminder ID: %w", err)
+	}
+	return id, nil
+}
+
+// GetDueReminders returns unsent reminders whose time is at or before now, oldest first.
+// A zero now means the storage clock's current time.
+func (s *SQLiteStorage) GetDueReminders(ctx context.Context, now time.Time) ([]*types.Reminder, error) {
+	if now.IsZero() {
+		now = s.now()
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT r.id, r.issue_id, r.remind_at, r.note, r.recipient, r.sent_at
+		FROM reminders r
+		JOIN issues i ON i.id = r.issue_id
+		WHERE r.sent_at IS NULL AND julianday(r.remind_at) <= julianday(?)
+		  AND (? = '' OR i.project_id = ?)
+		ORDER BY r.remind_at ASC, r.id ASC
+	`, now.UTC(), s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due reminders: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var reminders []*types.Reminder
+	for rows.Next() {
+		var r types.Reminder
+		var sentAt sql.NullTime
+		if err := rows.Scan(&r.ID, &r.IssueID, &r.RemindAt, &r.Note, &r.Recipient, &sentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reminder: %w", err)
+		}
+		if sentAt.Valid {
+			r.SentAt = &sentAt.Time
+		}
+		reminders = append(reminders, &r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// MarkReminderSent records that a reminder was delivered so it is no longer due
+func (s *SQLiteStorage) MarkReminderSent(ctx context.Context, reminderID int64) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE reminders SET sent_at = ?
+		WHERE id = ? AND sent_at IS NULL
+	`, s.now(), reminderID)
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("reminder %d not found or already sent", reminderID)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestReminders(t *testing.T) {
+	clock := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Follow up", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	id, err := store.AddReminder(ctx, issue.ID, clock.Add(2*time.Hour), "check in", "alice")
+	if err != nil {
+		t.Fatalf("AddReminder failed: %v", err)
+	}
+	if _, err := store.AddReminder(ctx, issue.ID, clock.Add(48*time.Hour), "later", "bob"); err != nil {
+		t.Fatalf("AddReminder failed: %v", err)
+	}
+	if _, err := store.AddReminder(ctx, issue.ID, clock, "", " "); err == nil {
+		t.Error("Expected error for missing recipient")
+	}
+
+	due, err := store.GetDueReminders(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("GetDueReminders failed: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("Expected nothing due yet, got %d", len(due))
+	}
+
+	// Advance the clock past the first reminder
+	clock = clock.Add(3 * time.Hour)
+	due, err = store.GetDueReminders(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("GetDueReminders failed: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != id || due[0].Recipient != "alice" || due[0].Note != "check in" {
+		t.Fatalf("Expected alice's reminder to be due, got %+v", due)
+	}
+
+	if err := store.MarkReminderSent(ctx, id); err != nil {
+		t.Fatalf("MarkReminderSent failed: %v", err)
+	}
+	if err := store.MarkReminderSent(ctx, id); err == nil {
+		t.Error("Expected error marking a reminder sent twice")
+	}
+	due, _ = store.GetDueReminders(ctx, time.Time{})
+	if len(due) != 0 {
+		t.Errorf("Expected sent reminder to no longer be due, got %d", len(due))
+	}
+
+	// Reminders are removed with their issue
+	if _, err := store.db.Exec("DELETE FROM issues WHERE id = ?", issue.ID); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+	due, _ = store.GetDueReminders(ctx, clock.Add(72*time.Hour))
+	if len(due) != 0 {
+		t.Errorf("Expected reminders to be cascade deleted, got %d", len(due))
+	}
+}
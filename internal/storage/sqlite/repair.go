@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// danglingDependency matches dependency rows whose issue or target doesn't exist
+const danglingDependency = `issue_id NOT IN (SELECT id FROM issues) OR depends_on_id NOT IN (SELECT id FROM issues)`
+
+// RepairRelationships removes dependency rows whose issue or target no longer
+// exists, as can happen after manual edits or partial imports made with foreign
+// keys off. Links and parent-child relationships live in the dependencies table
+// too and are counted separately in the report. All repairs run in one transaction.
+func (s *SQLiteStorage) RepairRelationships(ctx context.Context) (*types.RepairReport, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT type, COUNT(*) FROM dependencies
+		WHERE `+danglingDependency+`
+		GROUP BY type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dangling dependencies: %w", err)
+	}
+	report := &types.RepairReport{}
+	for rows.Next() {
+		var depType types.DependencyType
+		var count int
+		if err := rows.Scan(&depType, &count); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan dangling dependency count: %w", err)
+		}
+		switch depType {
+		case types.DepRelated:
+			report.Links += count
+		case types.DepParentChild:
+			report.ParentLinks += count
+		default:
+			report.Dependencies += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("error iterating dangling dependencies: %w", err)
+	}
+	_ = rows.Close()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dependencies WHERE `+danglingDependency); err != nil {
+		return nil, fmt.Errorf("failed to remove dangling dependencies: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit repairs: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestRepairRelationships(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// Foreign keys are per connection, so insert the dangling rows on one with them off
+	conn, err := store.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get connection: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatalf("Failed to disable foreign keys: %v", err)
+	}
+	for _, dep := range []struct {
+		issueID, dependsOnID string
+		depType              types.DependencyType
+	}{
+		{a.ID, "vc-gone-1", types.DepBlocks},
+		{"vc-gone-2", a.ID, types.DepDiscoveredFrom},
+		{a.ID, "vc-gone-3", types.DepRelated},
+		{"vc-gone-4", b.ID, types.DepParentChild},
+	} {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by)
+			VALUES (?, ?, ?, 'test')
+		`, dep.issueID, dep.dependsOnID, dep.depType)
+		if err != nil {
+			t.Fatalf("Failed to insert dangling dependency: %v", err)
+		}
+	}
+	_, _ = conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`)
+	_ = conn.Close()
+
+	report, err := store.RepairRelationships(ctx)
+	if err != nil {
+		t.Fatalf("RepairRelationships failed: %v", err)
+	}
+	if report.Dependencies != 2 || report.Links != 1 || report.ParentLinks != 1 || report.Total() != 4 {
+		t.Errorf("Unexpected repair report: %+v", report)
+	}
+
+	var remaining int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM dependencies`).Scan(&remaining); err != nil {
+		t.Fatalf("Failed to count dependencies: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("Expected only the valid dependency to remain, got %d", remaining)
+	}
+
+	// Nothing left to repair
+	report, err = store.RepairRelationships(ctx)
+	if err != nil || report.Total() != 0 {
+		t.Errorf("Expected an empty second repair, got %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,231 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// GetThinIssues returns non-closed issues whose description and acceptance
+// criteria together are shorter than minDescriptionChars, so reviewers can ask
+// for more detail. NULL text counts as zero length.
+func (s *SQLiteStorage) GetThinIssues(ctx context.Context, minDescriptionChars int) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE i.status != ?
+		  AND LENGTH(TRIM(COALESCE(i.description, ''))) + LENGTH(TRIM(COALESCE(i.acceptance_criteria, ''))) < ?
+		  AND (? = '' OR i.project_id = ?)
+		ORDER BY i.priority ASC, i.created_at DESC
+	`, types.StatusClosed, minDescriptionChars, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thin issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
+
+// GetAgeDistribution counts open (non-closed) issues matching filter by how long ago
+// they were created, for backlog-health reporting. Ages are measured against the
+// storage clock; every bucket is present in the result, even when zero.
+func (s *SQLiteStorage) GetAgeDistribution(ctx context.Context, filter types.IssueFilter) (map[string]int, error) {
+	whereSQL, args := s.buildIssueWhere("", filter)
+	if whereSQL == "" {
+		whereSQL = "WHERE i.status != ?"
+	} else {
+		whereSQL += " AND i.status != ?"
+	}
+	args = append(args, types.StatusClosed)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT i.created_at FROM issues i `+whereSQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue ages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	buckets := map[string]int{
+		types.AgeBucketToday:     0,
+		types.AgeBucketThisWeek:  0,
+		types.AgeBucketThisMonth: 0,
+		types.AgeBucketOlder:     0,
+	}
+	now := s.now()
+	for rows.Next() {
+		var createdAt time.Time
+		if err := rows.Scan(&createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan created_at: %w", err)
+		}
+		switch age := now.Sub(createdAt); {
+		case age < 24*time.Hour:
+			buckets[types.AgeBucketToday]++
+		case age < 7*24*time.Hour:
+			buckets[types.AgeBucketThisWeek]++
+		case age < 30*24*time.Hour:
+			buckets[types.AgeBucketThisMonth]++
+		default:
+			buckets[types.AgeBucketOlder]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating issue ages: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// GetThroughput counts issues created and closed in the window [from, to), with
+// net = created - closed. A positive net means the backlog grew over the window.
+func (s *SQLiteStorage) GetThroughput(ctx context.Context, from, to time.Time) (closed int, created int, net int, err error) {
+	err = s.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN julianday(created_at) >= julianday(?) AND julianday(created_at) < julianday(?) THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN closed_at IS NOT NULL AND julianday(closed_at) >= julianday(?) AND julianday(closed_at) < julianday(?) THEN 1 ELSE 0 END), 0)
+		FROM issues
+		WHERE (? = '' OR project_id = ?)
+	`, from.UTC(), to.UTC(), from.UTC(), to.UTC(), s.opts.project, s.opts.project).Scan(&created, &closed)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to get throughput: %w", err)
+	}
+	return closed, created, created - closed, nil
+}
+
+// GetEstimateAccuracy returns the average ratio of actual to estimated time across
+// issues closed in the window [from, to). Actual time is the total time logged
+// against the issue with LogWork; the estimate is estimated_minutes. Issues missing
+// either are skipped, and a window with none yields 0. A ratio above 1 means work
+// took longer than estimated.
+func (s *SQLiteStorage) GetEstimateAccuracy(ctx context.Context, from, to time.Time) (avgRatio float64, err error) {
+	var ratio sql.NullFloat64
+	err = s.db.QueryRowContext(ctx, `
+		SELECT AVG(h.actual_minutes / i.estimated_minutes)
+		FROM issues i
+		JOIN (
+			SELECT issue_id, SUM(minutes) * 1.0 AS actual_minutes
+			FROM worklog
+			GROUP BY issue_id
+		) h ON h.issue_id = i.id
+		WHERE i.closed_at IS NOT NULL
+		  AND julianday(i.closed_at) >= julianday(?) AND julianday(i.closed_at) < julianday(?)
+		  AND i.estimated_minutes > 0 AND h.actual_minutes > 0
+		  AND (? = '' OR i.project_id = ?)
+	`, from.UTC(), to.UTC(), s.opts.project, s.opts.project).Scan(&ratio)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get estimate accuracy: %w", err)
+	}
+	return ratio.Float64, nil
+}
+
+// GetNeverTouchedIssues returns non-closed issues with no activity since they were
+// filed: no events other than their creation (views don't count). These are the
+// filed-and-forgotten issues, as opposed to stale ones that were once discussed.
+func (s *SQLiteStorage) GetNeverTouchedIssues(ctx context.Context) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE i.status != ?
+		  AND (? = '' OR i.project_id = ?)
+		  AND NOT EXISTS (
+			SELECT 1 FROM events e
+			WHERE e.issue_id = i.id AND e.event_type NOT IN (?, ?)
+		  )
+		ORDER BY i.created_at ASC, i.id ASC
+	`, types.StatusClosed, s.opts.project, s.opts.project, types.EventCreated, types.EventViewed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get never-touched issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
+
+// GetWorkload counts non-closed, unarchived issues per assignee. Unassigned issues
+// are counted under "".
+func (s *SQLiteStorage) GetWorkload(ctx context.Context) (map[string]int, error) {
+	workload, _, err := s.workloadAndStaleness(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
+
+// ExportMetrics bundles GetStatistics, GetWorkload, throughput over the last
+// types.MetricsThroughputWindow and staleness counts into one serializable snapshot,
+// so a monitoring job can scrape everything in a single call. Workload and staleness
+// share one grouped query.
+func (s *SQLiteStorage) ExportMetrics(ctx context.Context) (*types.Metrics, error) {
+	now := s.now()
+	stats, err := s.GetStatistics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workload, staleness, err := s.workloadAndStaleness(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from := now.Add(-types.MetricsThroughputWindow)
+	closed, created, net, err := s.GetThroughput(ctx, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Metrics{
+		GeneratedAt: now,
+		Statistics:  *stats,
+		Workload:    workload,
+		Throughput:  types.Throughput{From: from, To: now, Created: created, Closed: closed, Net: net},
+		Staleness:   staleness,
+	}, nil
+}
+
+// workloadAndStaleness counts non-closed, unarchived issues per assignee and by time
+// since their last update, bucketed like GetAgeDistribution. Every staleness bucket
+// is present in the result, even when zero.
+func (s *SQLiteStorage) workloadAndStaleness(ctx context.Context) (workload, staleness map[string]int, err error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT assignee, COUNT(*),
+			SUM(CASE WHEN idle < 1 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN idle >= 1 AND idle < 7 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN idle >= 7 AND idle < 30 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN idle >= 30 THEN 1 ELSE 0 END)
+		FROM (
+			SELECT COALESCE(assignee, '') AS assignee, julianday(?) - julianday(updated_at) AS idle
+			FROM issues
+			WHERE status != ? AND archived_at IS NULL
+			  AND (? = '' OR project_id = ?)
+		)
+		GROUP BY assignee
+	`, s.now().UTC(), types.StatusClosed, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workload: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	workload = make(map[string]int)
+	staleness = map[string]int{
+		types.AgeBucketToday:     0,
+		types.AgeBucketThisWeek:  0,
+		types.AgeBucketThisMonth: 0,
+		types.AgeBucketOlder:     0,
+	}
+	for rows.Next() {
+		var assignee string
+		var count, today, week, month, older int
+		if err := rows.Scan(&assignee, &count, &today, &week, &month, &older); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan workload: %w", err)
+		}
+		workload[assignee] = count
+		staleness[types.AgeBucketToday] += today
+		staleness[types.AgeBucketThisWeek] += week
+		staleness[types.AgeBucketThisMonth] += month
+		staleness[types.AgeBucketOlder] += older
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating workload: %w", err)
+	}
+
+	return workload, staleness, nil
+}
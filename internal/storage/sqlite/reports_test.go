@@ -0,0 +1,312 @@
+package sqlite
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestGetThinIssues(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	create := func(title, description, criteria string, status types.Status) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{
+			Title: title, Description: description, AcceptanceCriteria: criteria,
+			Status: status, Priority: 2, IssueType: types.TypeTask,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		return issue
+	}
+
+	detailed := strings.Repeat("context ", 10)
+	create("Detailed", detailed, "", types.StatusOpen)
+	create("Detailed criteria", "short", detailed, types.StatusOpen)
+	sparse := create("Sparse", "fix it", "", types.StatusOpen)
+	empty := create("Empty", "", "", types.StatusInProgress)
+	create("Closed sparse", "", "", types.StatusClosed)
+
+	thin, err := store.GetThinIssues(ctx, 40)
+	if err != nil {
+		t.Fatalf("GetThinIssues failed: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, issue := range thin {
+		got[issue.ID] = true
+	}
+	for _, want := range []*types.Issue{sparse, empty} {
+		if !got[want.ID] {
+			t.Errorf("Expected %s (%s) to be thin", want.ID, want.Title)
+		}
+	}
+	if len(thin) != 2 {
+		t.Errorf("Expected 2 thin issues, got %d", len(thin))
+	}
+}
+
+func TestGetAgeDistribution(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	clock := now
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	seed := []struct {
+		age    time.Duration
+		status types.Status
+		typ    types.IssueType
+	}{
+		{2 * time.Hour, types.StatusOpen, types.TypeBug},
+		{3 * 24 * time.Hour, types.StatusInProgress, types.TypeBug},
+		{5 * 24 * time.Hour, types.StatusOpen, types.TypeTask},
+		{20 * 24 * time.Hour, types.StatusBlocked, types.TypeBug},
+		{90 * 24 * time.Hour, types.StatusOpen, types.TypeTask},
+		{90 * 24 * time.Hour, types.StatusClosed, types.TypeBug},
+	}
+	for _, sd := range seed {
+		clock = now.Add(-sd.age)
+		issue := &types.Issue{Title: "Aged", Status: sd.status, Priority: 2, IssueType: sd.typ}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	clock = now
+
+	got, err := store.GetAgeDistribution(ctx, types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("GetAgeDistribution failed: %v", err)
+	}
+	want := map[string]int{
+		types.AgeBucketToday:     1,
+		types.AgeBucketThisWeek:  2,
+		types.AgeBucketThisMonth: 1,
+		types.AgeBucketOlder:     1,
+	}
+	for bucket, count := range want {
+		if got[bucket] != count {
+			t.Errorf("Bucket %s: expected %d, got %d", bucket, count, got[bucket])
+		}
+	}
+
+	bug := types.TypeBug
+	got, err = store.GetAgeDistribution(ctx, types.IssueFilter{IssueType: &bug})
+	if err != nil {
+		t.Fatalf("GetAgeDistribution failed: %v", err)
+	}
+	if got[types.AgeBucketToday] != 1 || got[types.AgeBucketThisWeek] != 1 ||
+		got[types.AgeBucketThisMonth] != 1 || got[types.AgeBucketOlder] != 0 {
+		t.Errorf("Unexpected bug distribution: %v", got)
+	}
+}
+
+func TestGetThroughput(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock := start
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	create := func(at time.Time) *types.Issue {
+		clock = at
+		issue := &types.Issue{Title: "Throughput", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	closeAt := func(issue *types.Issue, at time.Time) {
+		clock = at
+		if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+	}
+
+	// Created before the window and closed inside it
+	old := create(start.Add(-48 * time.Hour))
+	closeAt(old, start.Add(time.Hour))
+	// Created and closed inside the window
+	quick := create(start.Add(2 * time.Hour))
+	closeAt(quick, start.Add(3*time.Hour))
+	// Created inside the window, still open
+	create(start.Add(4 * time.Hour))
+	create(start.Add(5 * time.Hour))
+	// Created inside, closed after the window
+	late := create(start.Add(6 * time.Hour))
+	closeAt(late, start.Add(48*time.Hour))
+
+	closed, created, net, err := store.GetThroughput(ctx, start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetThroughput failed: %v", err)
+	}
+	if closed != 2 || created != 4 || net != 2 {
+		t.Errorf("Expected closed=2 created=4 net=2, got closed=%d created=%d net=%d", closed, created, net)
+	}
+}
+
+func TestGetNeverTouchedIssues(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"Forgotten", "Discussed", "Viewed", "Done"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	if err := store.AddComment(ctx, issues[1].ID, "alice", "Any update?"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := store.GetIssueTracked(ctx, issues[2].ID, "alice"); err != nil {
+		t.Fatalf("GetIssueTracked failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issues[3].ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	got, err := store.GetNeverTouchedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetNeverTouchedIssues failed: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != issues[0].ID || got[1].ID != issues[2].ID {
+		ids := make([]string, len(got))
+		for i, issue := range got {
+			ids[i] = issue.ID
+		}
+		t.Errorf("Expected only the untouched and merely viewed issues, got %v", ids)
+	}
+}
+
+func TestGetEstimateAccuracy(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock := start
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	// issue estimates minutes, logs the given work, and closes at closedAt
+	issue := func(estimate *int, closedAt time.Time, logged ...int) {
+		t.Helper()
+		clock = start.Add(-24 * time.Hour)
+		issue := &types.Issue{Title: "Estimated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: estimate}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, m := range logged {
+			if err := store.LogWork(ctx, issue.ID, m, "test", ""); err != nil {
+				t.Fatalf("LogWork failed: %v", err)
+			}
+		}
+		clock = closedAt
+		if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+	}
+	minutes := func(n int) *int { return &n }
+
+	inWindow := start.Add(time.Hour)
+	// 90 minutes against an estimate of 60: 1.5
+	issue(minutes(60), inWindow, 90)
+	// Two 30 minute entries against an estimate of 80: 0.75
+	issue(minutes(80), inWindow, 30, 30)
+	// Skipped: no estimate, no logged time, closed outside the window
+	issue(nil, inWindow, 60)
+	issue(minutes(30), inWindow)
+	issue(minutes(10), start.Add(48*time.Hour), 60)
+
+	ratio, err := store.GetEstimateAccuracy(ctx, start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetEstimateAccuracy failed: %v", err)
+	}
+	if math.Abs(ratio-1.125) > 1e-6 {
+		t.Errorf("Expected an average ratio of 1.125, got %v", ratio)
+	}
+
+	ratio, err = store.GetEstimateAccuracy(ctx, start.Add(-72*time.Hour), start.Add(-48*time.Hour))
+	if err != nil || ratio != 0 {
+		t.Errorf("Expected 0 for an empty window, got %v (%v)", ratio, err)
+	}
+}
+
+func TestExportMetrics(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock := start
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	create := func(at time.Time, assignee string) *types.Issue {
+		clock = at
+		issue := &types.Issue{Title: "Metrics", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+
+	create(start.Add(-40*24*time.Hour), "alice")
+	create(start.Add(-10*24*time.Hour), "alice")
+	create(start.Add(-3*24*time.Hour), "bob")
+	create(start.Add(-time.Hour), "")
+	done := create(start.Add(-2*24*time.Hour), "bob")
+	clock = start.Add(-24 * time.Hour)
+	if err := store.CloseIssue(ctx, done.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	clock = start
+
+	metrics, err := store.ExportMetrics(ctx)
+	if err != nil {
+		t.Fatalf("ExportMetrics failed: %v", err)
+	}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	if metrics.Statistics != *stats {
+		t.Errorf("Expected statistics %+v, got %+v", *stats, metrics.Statistics)
+	}
+
+	workload, err := store.GetWorkload(ctx)
+	if err != nil {
+		t.Fatalf("GetWorkload failed: %v", err)
+	}
+	if len(workload) != 3 || workload["alice"] != 2 || workload["bob"] != 1 || workload[""] != 1 {
+		t.Errorf("Unexpected workload: %v", workload)
+	}
+	if len(metrics.Workload) != len(workload) {
+		t.Errorf("Expected workload %v, got %v", workload, metrics.Workload)
+	}
+	for assignee, n := range workload {
+		if metrics.Workload[assignee] != n {
+			t.Errorf("Expected workload %v, got %v", workload, metrics.Workload)
+		}
+	}
+
+	from := start.Add(-types.MetricsThroughputWindow)
+	closed, created, net, err := store.GetThroughput(ctx, from, start)
+	if err != nil {
+		t.Fatalf("GetThroughput failed: %v", err)
+	}
+	want := types.Throughput{From: from, To: start, Created: created, Closed: closed, Net: net}
+	if metrics.Throughput != want {
+		t.Errorf("Expected throughput %+v, got %+v", want, metrics.Throughput)
+	}
+	if created != 3 || closed != 1 {
+		t.Errorf("Expected created=3 closed=1, got created=%d closed=%d", created, closed)
+	}
+
+	if metrics.Staleness[types.AgeBucketToday] != 1 || metrics.Staleness[types.AgeBucketThisWeek] != 1 ||
+		metrics.Staleness[types.AgeBucketThisMonth] != 1 || metrics.Staleness[types.AgeBucketOlder] != 1 {
+		t.Errorf("Unexpected staleness: %v", metrics.Staleness)
+	}
+	if !metrics.GeneratedAt.Equal(start) {
+		t.Errorf("Expected GeneratedAt %v, got %v", start, metrics.GeneratedAt)
+	}
+}
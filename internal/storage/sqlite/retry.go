@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// RetryPolicy configures how write transactions retry when the database is busy
+// (see WithBusyRetry). Retry n (counting from 0) waits BaseDelay*2^n, capped at
+// MaxDelay, with up to the Jitter fraction of that delay randomly taken off so
+// concurrent writers don't retry in lockstep.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first; values
+	// below 2 disable retrying
+	MaxAttempts int
+
+	// BaseDelay is the wait before the first retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the wait between attempts; 0 means no cap
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0-1) of each delay that is randomized
+	Jitter float64
+}
+
+// delay returns the wait before retry n, given a random value in [0, 1)
+func (p RetryPolicy) delay(n int, random float64) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < n && (p.MaxDelay == 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d - time.Duration(float64(d)*p.Jitter*random)
+}
+
+// isBusyError reports whether err is SQLite's SQLITE_BUSY or SQLITE_LOCKED
+func isBusyError(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+}
+
+// retryBusy runs fn until it succeeds, fails with an error other than a busy error,
+// or the policy's attempts run out. sleep waits between attempts and random supplies
+// the jitter; both are parameters so tests can control them.
+func retryBusy(ctx context.Context, policy RetryPolicy, sleep func(context.Context, time.Duration) error, random func() float64, fn func() error) error {
+	err := fn()
+	for n := 0; n+1 < policy.MaxAttempts && isBusyError(err); n++ {
+		if sleepErr := sleep(ctx, policy.delay(n, random())); sleepErr != nil {
+			return err
+		}
+		err = fn()
+	}
+	return err
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// beginImmediate starts a BEGIN IMMEDIATE transaction on conn, retrying per the
+// configured RetryPolicy while another writer holds the lock
+func (s *SQLiteStorage) beginImmediate(ctx context.Context, conn *sql.Conn) error {
+	return retryBusy(ctx, s.opts.busyRetry, sleepContext, rand.Float64, func() error {
+		_, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE")
+		return err
+	})
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestRetryBusyBackoff(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond, Jitter: 0.5}
+
+	var delays []time.Duration
+	sleep := func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	randoms := []float64{0, 0.5, 0, 0.999}
+	random := func() float64 {
+		r := randoms[0]
+		randoms = randoms[1:]
+		return r
+	}
+
+	busy := sqlite3.Error{Code: sqlite3.ErrBusy}
+	attempts := 0
+	err := retryBusy(ctx, policy, sleep, random, func() error {
+		attempts++
+		if attempts < 5 {
+			return busy
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected success on the last attempt, got %v", err)
+	}
+
+	// 10ms, 20ms less a quarter, 40ms, then capped at 50ms less almost half
+	want := []time.Duration{10 * time.Millisecond, 15 * time.Millisecond, 40 * time.Millisecond}
+	if len(delays) != 4 {
+		t.Fatalf("Expected 4 delays, got %v", delays)
+	}
+	for i, d := range want {
+		if delays[i] != d {
+			t.Errorf("Delay %d: expected %v, got %v", i, d, delays[i])
+		}
+	}
+	if delays[3] <= 25*time.Millisecond || delays[3] >= 26*time.Millisecond {
+		t.Errorf("Expected the capped delay to be jittered to just over 25ms, got %v", delays[3])
+	}
+
+	// Attempts run out
+	attempts = 0
+	randoms = []float64{0, 0}
+	err = retryBusy(ctx, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}, sleep, random, func() error {
+		attempts++
+		return busy
+	})
+	if !isBusyError(err) || attempts != 3 {
+		t.Errorf("Expected the busy error after 3 attempts, got %v after %d", err, attempts)
+	}
+
+	// Other errors aren't retried
+	attempts = 0
+	err = retryBusy(ctx, policy, sleep, random, func() error {
+		attempts++
+		return errors.New("boom")
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected a single attempt for a non-busy error, got %d", attempts)
+	}
+}
+
+func TestWithBusyRetryValidation(t *testing.T) {
+	for _, policy := range []RetryPolicy{
+		{MaxAttempts: -1},
+		{MaxAttempts: 3, BaseDelay: -time.Millisecond},
+		{MaxAttempts: 3, Jitter: 1.5},
+	} {
+		o := options{}
+		WithBusyRetry(policy)(&o)
+		if err := o.validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", policy)
+		}
+	}
+
+	store := setupTestDB(t, WithBusyRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Jitter: 0.2}))
+	if _, err := store.CreateIssues(context.Background(), nil, "test"); err != nil {
+		t.Errorf("Expected creation to work with retries configured, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// RequestReview asks reviewer to review an issue. The reviewer is separate from the
+// assignee; the change is recorded as an update event. Closed issues can't be sent
+// for review.
+func (s *SQLiteStorage) RequestReview(ctx context.Context, id, reviewer, actor string) error {
+	if strings.TrimSpace(reviewer) == "" {
+		return types.NewValidationError("reviewer", reviewer, types.ErrInvalidField, "reviewer is required")
+	}
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	if issue.Status == types.StatusClosed {
+		return fmt.Errorf("cannot request review of closed issue %s", id)
+	}
+	return s.UpdateIssue(ctx, id, map[string]interface{}{"reviewer": reviewer}, actor)
+}
+
+// GetReviewQueue returns the open issues awaiting review by reviewer, highest
+// priority first and then longest waiting
+func (s *SQLiteStorage) GetReviewQueue(ctx context.Context, reviewer string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE i.reviewer = ? AND i.status != ?
+		  AND (? = '' OR i.project_id = ?)
+		ORDER BY i.priority ASC, i.updated_at ASC, i.id ASC
+	`, reviewer, types.StatusClosed, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestRequestReview(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, priority := range []int{2, 1, 3} {
+		issue := &types.Issue{Title: "Change", Status: types.StatusInProgress, Priority: priority, IssueType: types.TypeTask, Assignee: "alice"}
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, issue := range issues[:2] {
+		if err := store.RequestReview(ctx, issue.ID, "bob", "alice"); err != nil {
+			t.Fatalf("RequestReview failed: %v", err)
+		}
+	}
+	if err := store.RequestReview(ctx, issues[2].ID, "carol", "alice"); err != nil {
+		t.Fatalf("RequestReview failed: %v", err)
+	}
+	if err := store.RequestReview(ctx, issues[2].ID, " ", "alice"); err == nil {
+		t.Error("Expected an error for an empty reviewer")
+	}
+
+	got, err := store.GetIssue(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Reviewer != "bob" || got.Assignee != "alice" {
+		t.Errorf("Expected reviewer bob alongside assignee alice, got %q/%q", got.Reviewer, got.Assignee)
+	}
+
+	queue, err := store.GetReviewQueue(ctx, "bob")
+	if err != nil {
+		t.Fatalf("GetReviewQueue failed: %v", err)
+	}
+	if len(queue) != 2 || queue[0].ID != issues[1].ID || queue[1].ID != issues[0].ID {
+		t.Errorf("Expected bob's queue to be %s then %s, got %v", issues[1].ID, issues[0].ID, queue)
+	}
+
+	// Closed issues leave the queue and can't be sent for review
+	if err := store.CloseIssue(ctx, issues[1].ID, "merged", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if queue, _ := store.GetReviewQueue(ctx, "bob"); len(queue) != 1 {
+		t.Errorf("Expected one issue left in bob's queue, got %v", queue)
+	}
+	if err := store.RequestReview(ctx, issues[1].ID, "carol", "bob"); err == nil {
+		t.Error("Expected an error requesting review of a closed issue")
+	}
+
+	reviewer := "carol"
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Reviewer: &reviewer})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issues[2].ID {
+		t.Errorf("Expected the reviewer filter to find %s, got %v", issues[2].ID, results)
+	}
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// issueColumns is the column list for a full types.Issue, qualified with the "i" alias.
+// Queries selecting issues use "FROM issues i" and scan with scanIssue/scanIssues,
+// so new issue columns only need to be added here and in scanIssue.
+const issueColumns = `i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.estimate_min_minutes, i.estimate_max_minutes, i.project_id, i.pinned, i.blocked_reason,
+		       i.reviewer, i.due_at, i.archived_at, i.created_at, i.updated_at, i.closed_at,
+		       i.approved_at, i.approved_by`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanIssue scans a row selected with issueColumns, decrypting any encrypted columns.
+// Any extra destinations are scanned from the columns following issueColumns.
+func (s *SQLiteStorage) scanIssue(row rowScanner, extra ...interface{}) (*types.Issue, error) {
+	var issue types.Issue
+	var closedAt, dueAt, archivedAt, approvedAt sql.NullTime
+	var estimatedMinutes, estimateMin, estimateMax sql.NullInt64
+	var assignee, blockedReason, approvedBy sql.NullString
+
+	dest := []interface{}{
+		&issue.ID, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&estimateMin, &estimateMax, &issue.ProjectID, &issue.Pinned, &blockedReason,
+		&issue.Reviewer, &dueAt, &archivedAt, &issue.CreatedAt, &issue.UpdatedAt, &closedAt,
+		&approvedAt, &approvedBy,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if closedAt.Valid {
+		issue.ClosedAt = &closedAt.Time
+	}
+	if dueAt.Valid {
+		issue.DueAt = &dueAt.Time
+	}
+	if archivedAt.Valid {
+		issue.ArchivedAt = &archivedAt.Time
+	}
+	if approvedAt.Valid {
+		issue.ApprovedAt = &approvedAt.Time
+	}
+	issue.EstimatedMinutes = nullIntPtr(estimatedMinutes)
+	issue.EstimateMinMinutes = nullIntPtr(estimateMin)
+	issue.EstimateMaxMinutes = nullIntPtr(estimateMax)
+	if assignee.Valid {
+		issue.Assignee = assignee.String
+	}
+	if blockedReason.Valid {
+		issue.BlockedReason = blockedReason.String
+	}
+	if approvedBy.Valid {
+		issue.ApprovedBy = approvedBy.String
+	}
+	if err := s.cipher.decryptIssue(&issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// scanIssues scans all rows selected with issueColumns
+func (s *SQLiteStorage) scanIssues(rows *sql.Rows) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	for rows.Next() {
+		issue, err := s.scanIssue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// nullIntPtr converts a nullable integer column to an optional int
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
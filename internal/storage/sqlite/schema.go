@@ -10,10 +10,18 @@ CREATE TABLE IF NOT EXISTS issues (
     acceptance_criteria TEXT NOT NULL DEFAULT '',
     notes TEXT NOT NULL DEFAULT '',
     status TEXT NOT NULL DEFAULT 'open',
-    priority INTEGER NOT NULL DEFAULT 2 CHECK(priority >= 0 AND priority <= 4),
+    priority INTEGER NOT NULL DEFAULT 2 CHECK(priority >= 0),
     issue_type TEXT NOT NULL DEFAULT 'task',
     assignee TEXT,
     estimated_minutes INTEGER,
+    estimate_min_minutes INTEGER,
+    estimate_max_minutes INTEGER,
+    project_id TEXT NOT NULL DEFAULT '',
+    pinned INTEGER NOT NULL DEFAULT 0,
+    blocked_reason TEXT,
+    reviewer TEXT NOT NULL DEFAULT '',
+    due_at DATETIME,
+    archived_at DATETIME,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     closed_at DATETIME,
@@ -51,6 +59,95 @@ CREATE TABLE IF NOT EXISTS labels (
 
 CREATE INDEX IF NOT EXISTS idx_labels_label ON labels(label);
 
+-- Votes table
+-- One row per voter per issue; the primary key prevents double-voting
+CREATE TABLE IF NOT EXISTS votes (
+    issue_id TEXT NOT NULL,
+    voter TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, voter),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Flags table
+-- Moderation/triage flags; a flag stays on the issue until resolved
+CREATE TABLE IF NOT EXISTS flags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    code TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    flagger TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at DATETIME,
+    resolved_by TEXT,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_flags_issue ON flags(issue_id);
+CREATE INDEX IF NOT EXISTS idx_flags_code ON flags(code);
+
+-- Checklist items table
+-- Ordered sub-steps of an issue, checked off individually
+CREATE TABLE IF NOT EXISTS checklist_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    done INTEGER NOT NULL DEFAULT 0,
+    position INTEGER NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_checklist_items_issue ON checklist_items(issue_id);
+
+-- Reminders table
+-- Scheduled notifications; an external notifier polls for due, unsent reminders
+CREATE TABLE IF NOT EXISTS reminders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    remind_at DATETIME NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    recipient TEXT NOT NULL,
+    sent_at DATETIME,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_due ON reminders(sent_at, remind_at);
+
+-- Worklog table
+-- Time actually spent on an issue, logged in minutes
+CREATE TABLE IF NOT EXISTS worklog (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    minutes INTEGER NOT NULL CHECK(minutes > 0),
+    actor TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    logged_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_worklog_issue ON worklog(issue_id);
+
+-- ID aliases table
+-- Other IDs an issue answers to, such as its ID in a tracker it was migrated from
+CREATE TABLE IF NOT EXISTS id_aliases (
+    alias TEXT PRIMARY KEY,
+    issue_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_id_aliases_issue ON id_aliases(issue_id);
+
+-- Watchers table
+-- Users who want notifications about an issue (see Hooks.OnWatchersNotify)
+CREATE TABLE IF NOT EXISTS watchers (
+    issue_id TEXT NOT NULL,
+    user TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, user),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Events table (audit trail)
 CREATE TABLE IF NOT EXISTS events (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -67,6 +164,34 @@ CREATE TABLE IF NOT EXISTS events (
 CREATE INDEX IF NOT EXISTS idx_events_issue ON events(issue_id);
 CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
 
+-- Comments table
+CREATE TABLE IF NOT EXISTS comments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    author TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at DATETIME,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_issue ON comments(issue_id);
+
+-- Event history of deleted issues, kept unless the deletion purged it.
+-- No FK constraint: the issues these events belong to no longer exist.
+CREATE TABLE IF NOT EXISTS deleted_events (
+    id INTEGER PRIMARY KEY,
+    issue_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    comment TEXT,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_deleted_events_issue ON deleted_events(issue_id);
+
 -- Ready work view
 CREATE VIEW IF NOT EXISTS ready_issues AS
 SELECT i.*
@@ -196,6 +321,21 @@ CREATE TABLE IF NOT EXISTS config (
     value TEXT NOT NULL
 );
 
+-- Status config table
+-- Board column order and category for each status; defaults are seeded below
+-- and INSERT OR IGNORE keeps any customizations on later opens
+CREATE TABLE IF NOT EXISTS status_config (
+    status TEXT PRIMARY KEY,
+    display_order INTEGER NOT NULL,
+    category TEXT NOT NULL CHECK(category IN ('todo', 'in-progress', 'done'))
+);
+
+INSERT OR IGNORE INTO status_config (status, display_order, category) VALUES
+    ('open', 0, 'todo'),
+    ('in_progress', 1, 'in-progress'),
+    ('blocked', 2, 'in-progress'),
+    ('closed', 3, 'done');
+
 -- Issue counters table
 -- Stores atomic counters for issue ID generation per prefix
 -- Uses INSERT...ON CONFLICT DO UPDATE for race-free ID generation
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// FindSimilarIssues returns non-closed issues whose titles are similar to the
+// given issue's title, most similar first. Similarity is the trigram overlap
+// (Jaccard index, 0.0-1.0) of the normalized titles, computed in Go over all
+// candidate issues; only matches at or above threshold are returned.
+// This powers "possible duplicate" hints.
+func (s *SQLiteStorage) FindSimilarIssues(ctx context.Context, id string, threshold float64) ([]*types.Issue, error) {
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		WHERE i.status != ? AND i.id != ?
+		  AND (? = '' OR i.project_id = ?)
+	`, types.StatusClosed, id, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidate issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	candidates, err := s.scanIssues(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	target := titleTrigrams(issue.Title)
+	type match struct {
+		issue *types.Issue
+		score float64
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		score := trigramSimilarity(target, titleTrigrams(candidate.Title))
+		if score >= threshold {
+			matches = append(matches, match{candidate, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	similar := make([]*types.Issue, len(matches))
+	for i, m := range matches {
+		similar[i] = m.issue
+	}
+	return similar, nil
+}
+
+// titleTrigrams returns the set of trigrams for a title. Like pg_trgm, the title
+// is lowercased, split into alphanumeric words, and each word is padded with two
+// leading spaces and one trailing space before extracting trigrams.
+func titleTrigrams(title string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	trigrams := make(map[string]struct{})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return trigrams
+}
+
+// trigramSimilarity returns the Jaccard index of two trigram sets
+func trigramSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestFindSimilarIssues(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	create := func(title string, status types.Status) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeBug}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		return issue
+	}
+
+	original := create("Login page crashes on submit", types.StatusOpen)
+	duplicate := create("Login page crash on submit!", types.StatusOpen)
+	closedDuplicate := create("Login page crashes on submit", types.StatusClosed)
+	different := create("Add dark mode to settings", types.StatusOpen)
+
+	similar, err := store.FindSimilarIssues(ctx, original.ID, 0.5)
+	if err != nil {
+		t.Fatalf("FindSimilarIssues failed: %v", err)
+	}
+
+	if len(similar) != 1 || similar[0].ID != duplicate.ID {
+		var ids []string
+		for _, issue := range similar {
+			ids = append(ids, issue.ID)
+		}
+		t.Fatalf("Expected only %s, got %v (closed %s and unrelated %s should be excluded)",
+			duplicate.ID, ids, closedDuplicate.ID, different.ID)
+	}
+
+	if _, err := store.FindSimilarIssues(ctx, "vc-9999", 0.5); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	same := trigramSimilarity(titleTrigrams("Fix the parser"), titleTrigrams("fix THE parser"))
+	if same != 1.0 {
+		t.Errorf("Expected identical normalized titles to score 1.0, got %f", same)
+	}
+
+	unrelated := trigramSimilarity(titleTrigrams("Fix the parser"), titleTrigrams("Add dark mode"))
+	if unrelated >= 0.2 {
+		t.Errorf("Expected unrelated titles to score low, got %f", unrelated)
+	}
+}
@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// GetSLAElapsed returns how long an issue's SLA clock has run: the time since creation
+// spent in statuses other than closed and those paused with WithSLAPausedStatuses.
+// Status history is reconstructed from the issue's events; an open issue's clock
+// runs until the storage clock's current time.
+func (s *SQLiteStorage) GetSLAElapsed(ctx context.Context, id string) (time.Duration, error) {
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if issue == nil {
+		return 0, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	spans, err := s.statusSpans(ctx, issue)
+	if err != nil {
+		return 0, err
+	}
+	var elapsed time.Duration
+	for _, span := range spans {
+		if s.slaRunning(span.status) {
+			elapsed += span.end.Sub(span.start)
+		}
+	}
+	return elapsed, nil
+}
+
+// GetTimeInStatus returns how long an issue has spent in each status it has been in,
+// reconstructed from its events the same way as GetSLAElapsed. Time in the current
+// status runs until the storage clock's current time. Returns ErrIssueNotFound if
+// the issue doesn't exist.
+func (s *SQLiteStorage) GetTimeInStatus(ctx context.Context, id string) (map[types.Status]time.Duration, error) {
+	issue, err := s.getIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	spans, err := s.statusSpans(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+	durations := make(map[types.Status]time.Duration)
+	for _, span := range spans {
+		durations[span.status] += span.end.Sub(span.start)
+	}
+	return durations, nil
+}
+
+// statusSpan is a stretch of time an issue spent in one status
+type statusSpan struct {
+	status     types.Status
+	start, end time.Time
+}
+
+// statusSpans reconstructs an issue's status history from its events, in order.
+// The last span is the current status and ends at the storage clock's current time.
+func (s *SQLiteStorage) statusSpans(ctx context.Context, issue *types.Issue) ([]statusSpan, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT event_type, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	// Legacy issues without a creation event start from the row's created_at
+	status := types.StatusOpen
+	since := issue.CreatedAt
+	var spans []statusSpan
+	for rows.Next() {
+		var eventType types.EventType
+		var newValue, comment sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&eventType, &newValue, &comment, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		next, ok := statusAfterEvent(eventType, newValue, comment)
+		if !ok {
+			continue
+		}
+		if eventType != types.EventCreated {
+			spans = append(spans, statusSpan{status: status, start: since, end: createdAt})
+		}
+		status, since = next, createdAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return append(spans, statusSpan{status: status, start: since, end: s.now()}), nil
+}
+
+// slaRunning reports whether the SLA clock runs while an issue is in status
+func (s *SQLiteStorage) slaRunning(status types.Status) bool {
+	return status != types.StatusClosed && !s.opts.slaPaused[status]
+}
+
+// statusAfterEvent returns the issue's status after an event, if the event sets it.
+// Creation and UpdateIssue events carry the status in new_value. CloseIssue events
+// have none; executor status changes carry only a comment and move the issue
+// between open and in_progress.
+func statusAfterEvent(eventType types.EventType, newValue, comment sql.NullString) (types.Status, bool) {
+	if newValue.Valid && newValue.String != "" {
+		var fields struct {
+			Status *types.Status `json:"status"`
+		}
+		if err := json.Unmarshal([]byte(newValue.String), &fields); err == nil && fields.Status != nil {
+			return *fields.Status, true
+		}
+	}
+
+	switch eventType {
+	case types.EventClosed:
+		return types.StatusClosed, true
+	case types.EventReopened:
+		return types.StatusOpen, true
+	case types.EventStatusChanged:
+		if newValue.Valid && newValue.String != "" {
+			return "", false
+		}
+		if strings.HasPrefix(comment.String, "Issue claimed") {
+			return types.StatusInProgress, true
+		}
+		return types.StatusOpen, true
+	}
+	return "", false
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestGetSLAElapsed(t *testing.T) {
+	created := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	clock := created
+	store := setupTestDB(t, WithSLAPausedStatuses(types.StatusBlocked), WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Customer ticket", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// open 1h, blocked (paused) 2h, in_progress until now
+	for _, step := range []struct {
+		at     time.Duration
+		status types.Status
+	}{{time.Hour, types.StatusBlocked}, {3 * time.Hour, types.StatusInProgress}} {
+		clock = created.Add(step.at)
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(step.status)}, "test"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	clock = created.Add(10 * time.Hour)
+
+	elapsed, err := store.GetSLAElapsed(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetSLAElapsed failed: %v", err)
+	}
+	if want := 8 * time.Hour; elapsed != want {
+		t.Errorf("Expected %v excluding paused time, got %v", want, elapsed)
+	}
+
+	// Closing stops the clock
+	clock = created.Add(4 * time.Hour)
+	if err := store.CloseIssue(ctx, issue.ID, "fixed", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	clock = created.Add(100 * time.Hour)
+
+	elapsed, err = store.GetSLAElapsed(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetSLAElapsed failed: %v", err)
+	}
+	if want := 2 * time.Hour; elapsed != want {
+		t.Errorf("Expected %v once closed, got %v", want, elapsed)
+	}
+
+	if _, err := store.GetSLAElapsed(ctx, "vc-9999"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
+
+func TestGetTimeInStatus(t *testing.T) {
+	created := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	clock := created
+	store := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Flow", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// open 1h, in_progress 2h, blocked 3h, in_progress 1h, then closed until now (5h)
+	for _, step := range []struct {
+		at     time.Duration
+		status types.Status
+	}{{time.Hour, types.StatusInProgress}, {3 * time.Hour, types.StatusBlocked}, {6 * time.Hour, types.StatusInProgress}} {
+		clock = created.Add(step.at)
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(step.status)}, "test"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	clock = created.Add(7 * time.Hour)
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	clock = created.Add(12 * time.Hour)
+
+	durations, err := store.GetTimeInStatus(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetTimeInStatus failed: %v", err)
+	}
+	want := map[types.Status]time.Duration{
+		types.StatusOpen:       time.Hour,
+		types.StatusInProgress: 3 * time.Hour,
+		types.StatusBlocked:    3 * time.Hour,
+		types.StatusClosed:     5 * time.Hour,
+	}
+	if len(durations) != len(want) {
+		t.Errorf("Expected %d statuses, got %v", len(want), durations)
+	}
+	for status, d := range want {
+		if durations[status] != d {
+			t.Errorf("Expected %v in %s, got %v", d, status, durations[status])
+		}
+	}
+
+	if _, err := store.GetTimeInStatus(ctx, "vc-9999"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
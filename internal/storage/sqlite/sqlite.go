@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -18,10 +20,30 @@ import (
 type SQLiteStorage struct {
 	db          *sql.DB
 	issuePrefix string // Prefix for issue IDs (e.g., "vc-", "bd-")
+	opts        options
+	maxPriority int           // Highest allowed priority number (see WithMaxPriority)
+	cipher      *columnCipher // Encrypts configured columns; nil unless WithColumnEncryption
+	closed      atomic.Bool   // Set by Close so repeated calls skip the checkpoint
+	cursorKey   []byte        // Signs SearchIssuesPage cursors (see resolveCursorKey)
+
+	// Statements for hot lookups, prepared once by New and closed by Close
+	getIssueStmt    *sql.Stmt
+	issueExistsStmt *sql.Stmt
+
+	autoAssignMu sync.Mutex // Serializes CreateIssueAutoAssign's read-advance of the rotation cursor
 }
 
 // New creates a new SQLite storage backend
-func New(path string) (*SQLiteStorage, error) {
+// Optional behavior can be enabled by passing Options (see options.go)
+func New(path string, opts ...Option) (*SQLiteStorage, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -34,12 +56,28 @@ func New(path string) (*SQLiteStorage, error) {
 	prefix := strings.TrimSuffix(filename, filepath.Ext(filename))
 	issuePrefix := prefix + "-"
 
+	if o.mirrorPath != "" {
+		if err := os.MkdirAll(filepath.Dir(o.mirrorPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create mirror directory: %w", err)
+		}
+	}
+
 	// Open database with WAL mode for better concurrency
-	db, err := sql.Open("sqlite3", path+"?_journal_mode=WAL&_foreign_keys=ON")
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	dsn := path + dsnParams
+	var db *sql.DB
+	var err error
+	if o.slowQueryThreshold > 0 || o.mirrorPath != "" {
+		db = sql.OpenDB(&connector{dsn: dsn, opts: &o})
+	} else {
+		db, err = sql.Open(driverName, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
 	}
 
+	db.SetConnMaxIdleTime(o.connMaxIdleTime)
+	db.SetConnMaxLifetime(o.connMaxLifetime)
+
 	// Test connection
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
@@ -55,6 +93,21 @@ func New(path string) (*SQLiteStorage, error) {
 		return nil, fmt.Errorf("failed to migrate issue_counters table: %w", err)
 	}
 
+	// Merge labels that differ only in case, from before labels were normalized
+	if err := migrateLabelCase(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate labels: %w", err)
+	}
+
+	// Let older databases store priorities above 4 (see WithMaxPriority)
+	if err := migratePriorityCheck(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate priority constraint: %w", err)
+	}
+
+	// Apply versioned migrations, once each (see schemaMigrations)
+	if err := applySchemaMigrations(db); err != nil {
+		return nil, fmt.Errorf("failed to apply schema migrations: %w", err)
+	}
+
 	// Check config table for issue_prefix (takes precedence over filename-based prefix)
 	// This allows sandboxes and other databases to override the prefix
 	var configPrefix string
@@ -68,39 +121,121 @@ func New(path string) (*SQLiteStorage, error) {
 	}
 	// Otherwise use the filename-based prefix set above
 
-	return &SQLiteStorage{
+	// An explicit prefix overrides both
+	if o.idPrefix != "" {
+		issuePrefix = o.idPrefix + "-"
+	}
+
+	// Project-scoped storage numbers IDs per project, using the project as the prefix
+	if o.project != "" {
+		issuePrefix = o.project + "-"
+	}
+
+	maxPriority, err := resolveMaxPriority(db, o.maxPriority)
+	if err != nil {
+		return nil, err
+	}
+	// The priority range is only known once resolved against the database
+	for issueType, priority := range o.typePriorities {
+		if err := types.ValidatePriority(priority, maxPriority); err != nil {
+			return nil, fmt.Errorf("invalid default priority for %s: %w", issueType, err)
+		}
+	}
+
+	var columns *columnCipher
+	if o.encryptionKey != nil {
+		if columns, err = newColumnCipher(o.encryptionKey, o.encryptedColumns); err != nil {
+			return nil, err
+		}
+	}
+
+	cursorKey, err := resolveCursorKey(db)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SQLiteStorage{
 		db:          db,
 		issuePrefix: issuePrefix,
-	}, nil
+		opts:        o,
+		maxPriority: maxPriority,
+		cipher:      columns,
+		cursorKey:   cursorKey,
+	}
+	if err := s.prepareStatements(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// getIssueSQL selects one issue by ID or alias; an exact ID match wins over an
+// alias (see AddIDAlias). Arguments: id, id, project, project, id.
+const getIssueSQL = `
+		SELECT ` + issueColumns + `
+		FROM issues i
+		WHERE (i.id = ? OR i.id = (SELECT issue_id FROM id_aliases WHERE alias = ?))
+		  AND (? = '' OR i.project_id = ?)
+		ORDER BY i.id = ? DESC
+		LIMIT 1`
+
+// issueExistsSQL checks for an issue by exact ID. Arguments: id, project, project.
+const issueExistsSQL = `SELECT 1 FROM issues WHERE id = ? AND (? = '' OR project_id = ?)`
+
+// prepareStatements prepares the statements of the hottest lookups, which GetIssue
+// and issueExists reuse instead of parsing their SQL on every call. database/sql
+// re-prepares them transparently on each pooled connection. The dynamic UPDATE
+// and search queries vary per call and aren't prepared.
+func (s *SQLiteStorage) prepareStatements() error {
+	var err error
+	if s.getIssueStmt, err = s.db.Prepare(getIssueSQL); err != nil {
+		return fmt.Errorf("failed to prepare GetIssue statement: %w", err)
+	}
+	if s.issueExistsStmt, err = s.db.Prepare(issueExistsSQL); err != nil {
+		_ = s.getIssueStmt.Close()
+		return fmt.Errorf("failed to prepare issue lookup statement: %w", err)
+	}
+	return nil
 }
 
 // getNextID determines the next issue ID to use (DEPRECATED - kept for backwards compatibility)
 // New code should rely on the atomic counter in issue_counters table
 func getNextID(db *sql.DB) (int, error) {
-	var maxID sql.NullString
-	err := db.QueryRow("SELECT MAX(id) FROM issues").Scan(&maxID)
+	// MAX(id) compares text, so "bd-9" would beat "bd-100": parse every ID instead
+	rows, err := db.Query("SELECT id FROM issues")
 	if err != nil {
 		// Propagate actual errors (network, permissions, etc.)
 		return 0, fmt.Errorf("failed to query max issue ID: %w", err)
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Empty table or NULL result - start from 1
-	if !maxID.Valid || maxID.String == "" {
-		return 1, nil
-	}
+	// Empty table - start from 1
+	maxNum := 0
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
 
-	// Parse "vc-123" or "bd-123" to get 123
-	parts := strings.Split(maxID.String, "-")
-	if len(parts) != 2 {
-		return 0, fmt.Errorf("invalid issue ID format: %s (expected prefix-number)", maxID.String)
-	}
+		// Parse "vc-123" or "bd-123" to get 123
+		parts := strings.Split(id, "-")
+		if len(parts) != 2 {
+			return 0, fmt.Errorf("invalid issue ID format: %s (expected prefix-number)", id)
+		}
 
-	var num int
-	if _, err := fmt.Sscanf(parts[1], "%d", &num); err != nil {
-		return 0, fmt.Errorf("failed to parse issue number from %s: %w", maxID.String, err)
+		var num int
+		if _, err := fmt.Sscanf(parts[1], "%d", &num); err != nil {
+			return 0, fmt.Errorf("failed to parse issue number from %s: %w", id, err)
+		}
+		if num > maxNum {
+			maxNum = num
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to query max issue ID: %w", err)
 	}
 
-	return num + 1, nil
+	return maxNum + 1, nil
 }
 
 // migrateIssueCountersTable checks if the issue_counters table needs initialization.
@@ -165,15 +300,25 @@ func migrateIssueCountersTable(db *sql.DB) error {
 
 // CreateIssue creates a new issue
 func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
-	// Validate issue before creating
-	if err := issue.Validate(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+	return s.createIssue(ctx, issue, actor, nil)
+}
+
+// CreateIssueWithOptions creates a new issue like CreateIssue, adjusted by opts.
+// With AssignToReporter the actor is assigned, for the "I'll do this myself" flow.
+func (s *SQLiteStorage) CreateIssueWithOptions(ctx context.Context, issue *types.Issue, opts types.CreateOptions, actor string) error {
+	if opts.AssignToReporter {
+		issue.Assignee = actor
 	}
+	return s.createIssue(ctx, issue, actor, nil)
+}
 
-	// Set timestamps
-	now := time.Now()
-	issue.CreatedAt = now
-	issue.UpdatedAt = now
+// createIssue creates a new issue. If afterInsert is non-nil it runs on the
+// transaction's connection once the issue row and its creation event are written,
+// so callers can add related rows atomically with the issue.
+func (s *SQLiteStorage) createIssue(ctx context.Context, issue *types.Issue, actor string, afterInsert func(conn *sql.Conn) error) error {
+	if err := s.prepareIssue(ctx, issue, actor); err != nil {
+		return err
+	}
 
 	// Acquire a dedicated connection for the transaction.
 	// This is necessary because we need to execute raw SQL ("BEGIN IMMEDIATE", "COMMIT")
@@ -191,7 +336,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	//
 	// We use raw Exec instead of BeginTx because database/sql doesn't support transaction
 	// modes in BeginTx, and the sqlite3 driver's BeginTx always uses DEFERRED mode.
-	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+	if err := s.beginImmediate(ctx, conn); err != nil {
 		return fmt.Errorf("failed to begin immediate transaction: %w", err)
 	}
 
@@ -204,6 +349,121 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		}
 	}()
 
+	if err := s.insertIssue(ctx, conn, issue, actor); err != nil {
+		return err
+	}
+
+	if afterInsert != nil {
+		if err := afterInsert(conn); err != nil {
+			return err
+		}
+	}
+
+	// Commit the transaction
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+
+	if hook := s.opts.hooks.OnAfterCreate; hook != nil {
+		hook(ctx, issue, actor)
+	}
+	return nil
+}
+
+// CreateIssues creates a batch of issues in a single transaction, which is much
+// faster than calling CreateIssue for each one, and returns their IDs in order.
+// Every issue is validated before anything is written; if any issue fails, or any
+// insert does, nothing is created. Generated IDs are numbered consecutively.
+func (s *SQLiteStorage) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) ([]string, error) {
+	for i, issue := range issues {
+		if err := s.prepareIssue(ctx, issue, actor); err != nil {
+			return nil, fmt.Errorf("issue %d: %w", i, err)
+		}
+	}
+
+	// Same transaction handling as createIssue
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := s.beginImmediate(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	// IDs assigned here are only kept if the batch commits
+	generated := make([]bool, len(issues))
+	defer func() {
+		if !committed {
+			for i, issue := range issues {
+				if generated[i] {
+					issue.ID = ""
+				}
+			}
+		}
+	}()
+
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		generated[i] = issue.ID == ""
+		if err := s.insertIssue(ctx, conn, issue, actor); err != nil {
+			return nil, fmt.Errorf("issue %d: %w", i, err)
+		}
+		ids[i] = issue.ID
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+
+	if hook := s.opts.hooks.OnAfterCreate; hook != nil {
+		for _, issue := range issues {
+			hook(ctx, issue, actor)
+		}
+	}
+	return ids, nil
+}
+
+// prepareIssue applies defaults and the before-create hook to an issue about to be
+// created, validates it, and sets its project and timestamps
+func (s *SQLiteStorage) prepareIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	s.applyCreateDefaults(issue)
+
+	if hook := s.opts.hooks.OnBeforeCreate; hook != nil {
+		if err := hook(ctx, issue, actor); err != nil {
+			return fmt.Errorf("before-create hook: %w", err)
+		}
+	}
+
+	// Validate issue before creating
+	if err := issue.ValidateWithMaxPriority(s.maxPriority); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Scoped storage always writes into its own project
+	if s.opts.project != "" {
+		issue.ProjectID = s.opts.project
+	}
+
+	// Set timestamps
+	now := s.now()
+	issue.CreatedAt = now
+	issue.UpdatedAt = now
+	return nil
+}
+
+// insertIssue generates the issue's ID if it has none, then writes the issue and its
+// creation event. conn must be inside a BEGIN IMMEDIATE transaction.
+func (s *SQLiteStorage) insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor string) error {
 	// Generate ID if not set (inside transaction to prevent race conditions)
 	if issue.ID == "" {
 		// Get prefix from issuePrefix (already set during initialization)
@@ -212,19 +472,20 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 
 		// Atomically initialize counter (if needed) and get next ID (within transaction)
 		// This ensures the counter starts from the max existing ID, not 1
-		// CRITICAL: We rely on BEGIN IMMEDIATE above to serialize this operation across processes
+		// CRITICAL: We rely on the caller's BEGIN IMMEDIATE to serialize this operation across processes
 		//
 		// The query works as follows:
 		// 1. Try to INSERT with last_id = MAX(existing IDs) or 0 if none exist, then +1
 		// 2. ON CONFLICT: update last_id to MAX(existing last_id, new calculated last_id) + 1
-		// 3. RETURNING gives us the final incremented value
+		// 3. A SELECT in the same transaction reads back the incremented value. This is
+		//    deliberately not RETURNING: the counter write must go through Exec so
+		//    WithMirror replays it, or a failed-over mirror would reuse IDs
 		//
 		// This atomically handles three cases:
 		// - Counter doesn't exist: initialize from existing issues and return next ID
 		// - Counter exists but lower than max ID: update to max and return next ID
 		// - Counter exists and correct: just increment and return next ID
-		var nextID int
-		err = conn.QueryRowContext(ctx, `
+		_, err := conn.ExecContext(ctx, `
 			INSERT INTO issue_counters (prefix, last_id)
 			SELECT ?, COALESCE(MAX(CAST(substr(id, LENGTH(?) + 2) AS INTEGER)), 0) + 1
 			FROM issues
@@ -238,73 +499,92 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 					 WHERE id LIKE ? || '-%'
 					   AND substr(id, LENGTH(?) + 2) GLOB '[0-9]*')
 				) + 1
-			RETURNING last_id
-		`, prefix, prefix, prefix, prefix, prefix, prefix, prefix).Scan(&nextID)
+		`, prefix, prefix, prefix, prefix, prefix, prefix, prefix)
 		if err != nil {
 			return fmt.Errorf("failed to generate next ID for prefix %s: %w", prefix, err)
 		}
+		var nextID int
+		err = conn.QueryRowContext(ctx, `SELECT last_id FROM issue_counters WHERE prefix = ?`, prefix).Scan(&nextID)
+		if err != nil {
+			return fmt.Errorf("failed to read next ID for prefix %s: %w", prefix, err)
+		}
 
 		issue.ID = fmt.Sprintf("%s-%d", prefix, nextID)
+		if s.opts.idChecksum {
+			issue.ID = withChecksum(issue.ID)
+		}
 	}
 
-	// Insert issue
+	// Insert issue, with any encrypted columns in their stored form
+	stored, err := s.cipher.encryptIssue(issue)
+	if err != nil {
+		return err
+	}
 	_, err = conn.ExecContext(ctx, `
 		INSERT INTO issues (
 			id, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			estimate_min_minutes, estimate_max_minutes, project_id, pinned,
+			reviewer, due_at, archived_at, created_at, updated_at, approved_at, approved_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
-		issue.ID, issue.Title, issue.Description, issue.Design,
-		issue.AcceptanceCriteria, issue.Notes, issue.Status,
-		issue.Priority, issue.IssueType, issue.Assignee,
-		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
+		stored.ID, stored.Title, stored.Description, stored.Design,
+		stored.AcceptanceCriteria, stored.Notes, stored.Status,
+		stored.Priority, stored.IssueType, stored.Assignee,
+		stored.EstimatedMinutes, stored.EstimateMinMinutes, stored.EstimateMaxMinutes,
+		stored.ProjectID, stored.Pinned, stored.Reviewer, stored.DueAt, stored.ArchivedAt, stored.CreatedAt, stored.UpdatedAt,
+		stored.ApprovedAt, sql.NullString{String: stored.ApprovedBy, Valid: stored.ApprovedBy != ""},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
 	}
 
 	// Record creation event
-	eventData, _ := json.Marshal(issue)
-	eventDataStr := string(eventData)
+	eventData, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
 	_, err = conn.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, new_value)
-		VALUES (?, ?, ?, ?)
-	`, issue.ID, types.EventCreated, actor, eventDataStr)
+		INSERT INTO events (issue_id, event_type, actor, new_value, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, issue.ID, types.EventCreated, actor, string(eventData), issue.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
+	return nil
+}
 
-	// Commit the transaction
-	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+// applyCreateDefaults fills in configured defaults and normalizations on an issue
+// about to be created, before it is validated
+func (s *SQLiteStorage) applyCreateDefaults(issue *types.Issue) {
+	if s.opts.normalizeTitles {
+		issue.Title = normalizeTitle(issue.Title)
+	}
+	if issue.IssueType == "" && s.opts.defaultIssueType != "" {
+		issue.IssueType = s.opts.defaultIssueType
+	}
+	if issue.Priority == types.PriorityUnset {
+		if priority, ok := s.opts.typePriorities[issue.IssueType]; ok {
+			issue.Priority = priority
+		}
 	}
-	committed = true
-
-	return nil
 }
 
-// GetIssue retrieves an issue by ID
+// GetIssue retrieves an issue by ID or by one of its aliases (see AddIDAlias).
+// Other methods take the canonical ID; use ResolveID to turn an alias into one.
+// A missing issue yields (nil, nil), or ErrIssueNotFound with WithNotFoundErrors.
 func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
-	var issue types.Issue
-	var closedAt sql.NullTime
-	var approvedAt sql.NullTime
-	var estimatedMinutes sql.NullInt64
-	var assignee sql.NullString
-	var approvedBy sql.NullString
+	issue, err := s.getIssue(ctx, id)
+	if err == nil && issue == nil && s.opts.notFoundErrors {
+		return nil, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	return issue, err
+}
 
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, approved_at, approved_by
-		FROM issues
-		WHERE id = ?
-	`, id).Scan(
-		&issue.ID, &issue.Title, &issue.Description, &issue.Design,
-		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &approvedAt, &approvedBy,
-	)
+// getIssue is GetIssue without WithNotFoundErrors: a missing issue is (nil, nil)
+func (s *SQLiteStorage) getIssue(ctx context.Context, id string) (*types.Issue, error) {
+	issue, err := s.scanIssue(s.getIssueStmt.QueryRowContext(ctx,
+		id, id, s.opts.project, s.opts.project, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -313,18 +593,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		return nil, fmt.Errorf("failed to get issue: %w", err)
 	}
 
-	if closedAt.Valid {
-		issue.ClosedAt = &closedAt.Time
-	}
-	if estimatedMinutes.Valid {
-		mins := int(estimatedMinutes.Int64)
-		issue.EstimatedMinutes = &mins
-	}
-	if assignee.Valid {
-		issue.Assignee = assignee.String
-	}
-
-	return &issue, nil
+	return issue, nil
 }
 
 // GetMission retrieves a mission by ID with approval metadata
@@ -341,8 +610,8 @@ func (s *SQLiteStorage) GetMission(ctx context.Context, id string) (*types.Missi
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, approved_at, approved_by
 		FROM issues
-		WHERE id = ?
-	`, id).Scan(
+		WHERE id = ? AND (? = '' OR project_id = ?)
+	`, id, s.opts.project, s.opts.project).Scan(
 		&mission.ID, &mission.Title, &mission.Description, &mission.Design,
 		&mission.AcceptanceCriteria, &mission.Notes, &mission.Status,
 		&mission.Priority, &mission.IssueType, &assignee, &estimatedMinutes,
@@ -375,106 +644,232 @@ func (s *SQLiteStorage) GetMission(ctx context.Context, id string) (*types.Missi
 	if approvedBy.Valid {
 		mission.ApprovedBy = approvedBy.String
 	}
+	if err := s.cipher.decryptIssue(&mission.Issue); err != nil {
+		return nil, err
+	}
 
 	return &mission, nil
 }
 
 // Allowed fields for update to prevent SQL injection
 var allowedUpdateFields = map[string]bool{
-	"status":              true,
-	"priority":            true,
-	"title":               true,
-	"assignee":            true,
-	"description":         true,
-	"design":              true,
-	"acceptance_criteria": true,
-	"notes":               true,
-	"issue_type":          true,
-	"estimated_minutes":   true,
-	"approved_at":         true,
-	"approved_by":         true,
-}
-
-// UpdateIssue updates fields on an issue
+	"status":               true,
+	"priority":             true,
+	"title":                true,
+	"assignee":             true,
+	"description":          true,
+	"design":               true,
+	"acceptance_criteria":  true,
+	"notes":                true,
+	"issue_type":           true,
+	"estimated_minutes":    true,
+	"estimate_min_minutes": true,
+	"estimate_max_minutes": true,
+	"reviewer":             true,
+	"due_at":               true,
+	"approved_at":          true,
+	"approved_by":          true,
+}
+
+// UpdateIssue updates fields on an issue.
+// Fields whose new value equals the current one are skipped (see UpdateIssueChanged).
 func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	_, err := s.UpdateIssueChanged(ctx, id, updates, actor)
+	return err
+}
+
+// UpdateIssueChanged updates fields on an issue and reports whether anything changed.
+// Updates that match the issue's current values are dropped before writing, so the
+// event's new_value lists only genuine changes. When nothing changes, no write or
+// event happens and changed is false.
+func (s *SQLiteStorage) UpdateIssueChanged(ctx context.Context, id string, updates map[string]interface{}, actor string) (bool, error) {
+	return s.updateIssue(ctx, id, updates, actor, nil)
+}
+
+// UpdateIssueIfUnchanged updates fields on an issue like UpdateIssue, but only if its
+// updated_at still equals expectedUpdatedAt (as read by GetIssue). Otherwise another
+// writer got there first and ErrConcurrentModification is returned with nothing
+// written, so callers can re-read and retry their read-modify-write. The guard
+// relies on each write moving updated_at, which a frozen WithClock clock won't do.
+func (s *SQLiteStorage) UpdateIssueIfUnchanged(ctx context.Context, id string, updates map[string]interface{}, expectedUpdatedAt time.Time, actor string) error {
+	_, err := s.updateIssue(ctx, id, updates, actor, &expectedUpdatedAt)
+	return err
+}
+
+// updateIssue implements UpdateIssueChanged; a non-nil expectedUpdatedAt guards the
+// write as described in UpdateIssueIfUnchanged
+func (s *SQLiteStorage) updateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string, expectedUpdatedAt *time.Time) (bool, error) {
 	// Get old issue for event
-	oldIssue, err := s.GetIssue(ctx, id)
+	oldIssue, err := s.getIssue(ctx, id)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if oldIssue == nil {
-		return fmt.Errorf("issue %s not found", id)
+		return false, fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	if expectedUpdatedAt != nil && !oldIssue.UpdatedAt.Equal(*expectedUpdatedAt) {
+		return false, fmt.Errorf("issue %s was updated at %s: %w", id, oldIssue.UpdatedAt.Format(time.RFC3339Nano), ErrConcurrentModification)
+	}
+
+	if title, ok := updates["title"].(string); ok && s.opts.normalizeTitles {
+		// Copy so the caller's map is left untouched
+		normalized := make(map[string]interface{}, len(updates))
+		for key, value := range updates {
+			normalized[key] = value
+		}
+		normalized["title"] = normalizeTitle(title)
+		updates = normalized
+	}
+
+	updates = changedUpdates(oldIssue, updates)
+	if len(updates) == 0 {
+		return false, nil
+	}
+
+	if hook := s.opts.hooks.OnBeforeUpdate; hook != nil {
+		if err := hook(ctx, id, updates, actor); err != nil {
+			return false, fmt.Errorf("before-update hook: %w", err)
+		}
+	}
+
+	// Encrypted columns are written, and recorded in the event, in their stored form
+	stored, err := s.cipher.encryptUpdates(updates)
+	if err != nil {
+		return false, err
 	}
 
 	// Build update query with validated field names
+	now := s.now()
 	setClauses := []string{"updated_at = ?"}
-	args := []interface{}{time.Now()}
+	args := []interface{}{now}
 
 	for key, value := range updates {
 		// Prevent SQL injection by validating field names
 		if !allowedUpdateFields[key] {
-			return fmt.Errorf("invalid field for update: %s", key)
+			return false, types.NewValidationError(key, value, types.ErrInvalidField, "invalid field for update: %s", key)
 		}
 
 		// Validate field values
 		switch key {
 		case "priority":
 			if priority, ok := value.(int); ok {
-				if priority < 0 || priority > 4 {
-					return fmt.Errorf("priority must be between 0 and 4 (got %d)", priority)
+				if err := types.ValidatePriority(priority, s.maxPriority); err != nil {
+					return false, err
 				}
 			}
 		case "status":
 			if status, ok := value.(string); ok {
 				if !types.Status(status).IsValid() {
-					return fmt.Errorf("invalid status: %s", status)
+					return false, types.NewValidationError(key, status, types.ErrInvalidStatus, "invalid status: %s", status)
 				}
 			}
 		case "issue_type":
 			if issueType, ok := value.(string); ok {
 				if !types.IssueType(issueType).IsValid() {
-					return fmt.Errorf("invalid issue type: %s", issueType)
+					return false, types.NewValidationError(key, issueType, types.ErrInvalidIssueType, "invalid issue type: %s", issueType)
 				}
 			}
 		case "title":
 			if title, ok := value.(string); ok {
 				if len(title) == 0 || len(title) > 500 {
-					return fmt.Errorf("title must be 1-500 characters")
+					return false, types.NewValidationError(key, title, types.ErrTitleLength, "title must be 1-500 characters")
 				}
 			}
-		case "estimated_minutes":
+		case "estimated_minutes", "estimate_min_minutes", "estimate_max_minutes":
 			if mins, ok := value.(int); ok {
 				if mins < 0 {
-					return fmt.Errorf("estimated_minutes cannot be negative")
+					return false, types.NewValidationError(key, mins, types.ErrInvalidEstimate, "%s cannot be negative", key)
 				}
 			}
 		}
 
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
-		args = append(args, value)
+		args = append(args, stored[key])
+	}
+	// Leaving blocked status drops the reason recorded by BlockIssue
+	if status, ok := updates["status"]; ok && fmt.Sprint(status) != string(types.StatusBlocked) {
+		setClauses = append(setClauses, "blocked_reason = NULL")
 	}
 	args = append(args, id)
 
+	if err := s.checkAssigneeRequired(oldIssue, updates); err != nil {
+		return false, err
+	}
+	if err := checkEstimateRange(oldIssue, updates); err != nil {
+		return false, err
+	}
+
+	// Marshal event data up front so a bad value fails before anything is written
+	oldStored, err := s.cipher.encryptIssue(oldIssue)
+	if err != nil {
+		return false, err
+	}
+	oldData, err := json.Marshal(oldStored)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	audited := make(map[string]interface{}, len(stored))
+	for key, value := range stored {
+		if !s.opts.unauditedFields[key] {
+			audited[key] = value
+		}
+	}
+	// An assignee change gets its own EventAssigned holding just the previous and new
+	// assignee, so handoffs can be audited (see GetAssignmentHistory)
+	var assignedOld, assignedNew []byte
+	if assignee, ok := audited["assignee"]; ok {
+		delete(audited, "assignee")
+		if assignedOld, err = json.Marshal(map[string]interface{}{"assignee": oldIssue.Assignee}); err != nil {
+			return false, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		if assignedNew, err = json.Marshal(map[string]interface{}{"assignee": assignee}); err != nil {
+			return false, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+	}
+	newData, err := json.Marshal(audited)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
 	// Start transaction
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Re-check the guard inside the transaction, in case a write landed since GetIssue
+	if expectedUpdatedAt != nil {
+		var current time.Time
+		if err := tx.QueryRowContext(ctx, `SELECT updated_at FROM issues WHERE id = ?`, id).Scan(&current); err != nil {
+			return false, fmt.Errorf("failed to read updated_at: %w", err)
+		}
+		if !current.Equal(*expectedUpdatedAt) {
+			return false, fmt.Errorf("issue %s was updated at %s: %w", id, current.Format(time.RFC3339Nano), ErrConcurrentModification)
+		}
+	}
+
+	// Count in-progress work inside the transaction, so concurrent updates can't both
+	// slip under the WIP limit
+	if err := s.checkWIPLimitForUpdate(ctx, tx, oldIssue, updates); err != nil {
+		return false, err
+	}
+
+	// Closing through UpdateIssue is gated the same way as CloseIssue
+	if status, ok := updates["status"]; ok && fmt.Sprint(status) == string(types.StatusClosed) && s.opts.checklistGatedClose {
+		if err := checkChecklistComplete(ctx, tx, id); err != nil {
+			return false, err
+		}
+	}
+
 	// Update issue
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", "))
 	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to update issue: %w", err)
+		return false, fmt.Errorf("failed to update issue: %w", err)
 	}
 
-	// Record event
-	oldData, _ := json.Marshal(oldIssue)
-	newData, _ := json.Marshal(updates)
-	oldDataStr := string(oldData)
-	newDataStr := string(newData)
-
+	// Record event, unless every changed field is unaudited (see WithUnauditedFields)
 	eventType := types.EventUpdated
 	if statusVal, ok := updates["status"]; ok {
 		if statusVal == string(types.StatusClosed) {
@@ -484,20 +879,106 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		}
 	}
 
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, ?, ?, ?)
-	`, id, eventType, actor, oldDataStr, newDataStr)
-	if err != nil {
-		return fmt.Errorf("failed to record event: %w", err)
+	// Watchers hear about each recorded event, and nothing if none was recorded
+	var recorded []types.EventType
+	if len(audited) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, id, eventType, actor, string(oldData), string(newData), now)
+		if err != nil {
+			return false, fmt.Errorf("failed to record event: %w", err)
+		}
+		recorded = append(recorded, eventType)
+	}
+	if assignedNew != nil {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, id, types.EventAssigned, actor, string(assignedOld), string(assignedNew), now)
+		if err != nil {
+			return false, fmt.Errorf("failed to record event: %w", err)
+		}
+		recorded = append(recorded, types.EventAssigned)
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	if hook := s.opts.hooks.OnAfterUpdate; hook != nil {
+		hook(ctx, id, updates, actor)
+	}
+	for _, recordedType := range recorded {
+		s.notifyWatchers(ctx, id, recordedType, actor)
+	}
+	return true, nil
+}
+
+// checkAssigneeRequired enforces WithAssigneeRequired: moving into a configured
+// status requires the issue to end up with a non-empty assignee
+func (s *SQLiteStorage) checkAssigneeRequired(oldIssue *types.Issue, updates map[string]interface{}) error {
+	if len(s.opts.assigneeRequired) == 0 {
+		return nil
+	}
+
+	var newStatus types.Status
+	switch v := updates["status"].(type) {
+	case string:
+		newStatus = types.Status(v)
+	case types.Status:
+		newStatus = v
+	default:
+		return nil
+	}
+	if newStatus == oldIssue.Status || !s.opts.assigneeRequired[newStatus] {
+		return nil
+	}
+
+	assignee := oldIssue.Assignee
+	if v, ok := updates["assignee"]; ok {
+		assignee, _ = v.(string)
+	}
+	if strings.TrimSpace(assignee) == "" {
+		return fmt.Errorf("cannot move issue %s to %s: %w", oldIssue.ID, newStatus, ErrAssigneeRequired)
+	}
+	return nil
+}
+
+// checkEstimateRange validates the point estimate and its bounds as they will be
+// after the update, so a partial update cannot leave min > point or point > max
+func checkEstimateRange(oldIssue *types.Issue, updates map[string]interface{}) error {
+	point, min, max := oldIssue.EstimatedMinutes, oldIssue.EstimateMinMinutes, oldIssue.EstimateMaxMinutes
+	for key, target := range map[string]**int{
+		"estimated_minutes":    &point,
+		"estimate_min_minutes": &min,
+		"estimate_max_minutes": &max,
+	} {
+		value, ok := updates[key]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case nil:
+			*target = nil
+		case int:
+			*target = &v
+		case *int:
+			*target = v
+		}
+	}
+	return types.ValidateEstimateRange(point, min, max)
 }
 
 // CloseIssue closes an issue with a reason
 func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
-	now := time.Now()
+	if hook := s.opts.hooks.OnBeforeClose; hook != nil {
+		if err := hook(ctx, id, reason, actor); err != nil {
+			return fmt.Errorf("before-close hook: %w", err)
+		}
+	}
+
+	now := s.now()
 
 	// Update with special event handling
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -506,56 +987,149 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if s.opts.checklistGatedClose {
+		if err := checkChecklistComplete(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, blocked_reason = NULL
+		WHERE id = ? AND (? = '' OR project_id = ?)
+	`, types.StatusClosed, now, now, id, s.opts.project, s.opts.project)
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
 	_, err = tx.ExecContext(ctx, `
-		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventClosed, actor, reason, now)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if hook := s.opts.hooks.OnAfterClose; hook != nil {
+		hook(ctx, id, reason, actor)
+	}
+	s.notifyWatchers(ctx, id, types.EventClosed, actor)
+	return nil
+}
+
+// ReopenIssue moves a closed issue back to open, clearing closed_at, and records an
+// EventReopened with reason as the comment. Returns ErrNotClosed if the issue isn't closed.
+func (s *SQLiteStorage) ReopenIssue(ctx context.Context, id string, reason string, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var status types.Status
+	err = tx.QueryRowContext(ctx, `
+		SELECT status FROM issues WHERE id = ? AND (? = '' OR project_id = ?)
+	`, id, s.opts.project, s.opts.project).Scan(&status)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if status != types.StatusClosed {
+		return fmt.Errorf("cannot reopen %s (status %s): %w", id, status, ErrNotClosed)
+	}
+
+	now := s.now()
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET status = ?, closed_at = NULL, updated_at = ?
 		WHERE id = ?
-	`, types.StatusClosed, now, now, id)
+	`, types.StatusOpen, now, id)
 	if err != nil {
-		return fmt.Errorf("failed to close issue: %w", err)
+		return fmt.Errorf("failed to reopen issue: %w", err)
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, id, types.EventClosed, actor, reason)
+		INSERT INTO events (issue_id, event_type, actor, comment, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventReopened, actor, reason, now)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.notifyWatchers(ctx, id, types.EventReopened, actor)
+	return nil
 }
 
-// SearchIssues finds issues matching query and filters
-func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+// buildIssueWhere builds the WHERE clause and arguments shared by issue queries
+// that accept a search query and IssueFilter. The issues table must be aliased as i.
+func (s *SQLiteStorage) buildIssueWhere(query string, filter types.IssueFilter) (string, []interface{}) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
+	if s.opts.project != "" {
+		whereClauses = append(whereClauses, "i.project_id = ?")
+		args = append(args, s.opts.project)
+	}
+
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
 		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		// An encrypted description holds ciphertext, so only title and ID can match
+		if s.cipher.encrypts("description") {
+			whereClauses = append(whereClauses, "(i.title LIKE ? OR i.id LIKE ?)")
+			args = append(args, pattern, pattern)
+		} else {
+			whereClauses = append(whereClauses, "(i.title LIKE ? OR i.description LIKE ? OR i.id LIKE ?)")
+			args = append(args, pattern, pattern, pattern)
+		}
 	}
 
+	if !filter.IncludeArchived {
+		whereClauses = append(whereClauses, "i.archived_at IS NULL")
+	}
+
+	// The single-value fields merge into their multi-value counterparts
+	statuses := filter.Statuses
 	if filter.Status != nil {
-		whereClauses = append(whereClauses, "status = ?")
-		args = append(args, *filter.Status)
+		statuses = append(statuses[:len(statuses):len(statuses)], *filter.Status)
 	}
+	whereClauses, args = appendInClause(whereClauses, args, "i.status", statuses)
 
+	priorities := filter.Priorities
 	if filter.Priority != nil {
-		whereClauses = append(whereClauses, "priority = ?")
-		args = append(args, *filter.Priority)
+		priorities = append(priorities[:len(priorities):len(priorities)], *filter.Priority)
 	}
+	whereClauses, args = appendInClause(whereClauses, args, "i.priority", priorities)
 
+	issueTypes := filter.IssueTypes
 	if filter.IssueType != nil {
-		whereClauses = append(whereClauses, "issue_type = ?")
-		args = append(args, *filter.IssueType)
+		issueTypes = append(issueTypes[:len(issueTypes):len(issueTypes)], *filter.IssueType)
 	}
+	whereClauses, args = appendInClause(whereClauses, args, "i.issue_type", issueTypes)
 
 	if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
+		whereClauses = append(whereClauses, "i.assignee = ?")
 		args = append(args, *filter.Assignee)
 	}
 
+	if filter.Reviewer != nil {
+		whereClauses = append(whereClauses, "i.reviewer = ?")
+		args = append(args, *filter.Reviewer)
+	}
+
 	// Handle label filtering (vc-243)
 	// Each label requires an EXISTS subquery to ensure ALL labels match
 	if len(filter.Labels) > 0 {
@@ -563,10 +1137,85 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 			whereClauses = append(whereClauses, `
 				EXISTS (
 					SELECT 1 FROM labels l
-					WHERE l.issue_id = issues.id AND l.label = ?
+					WHERE l.issue_id = i.id AND l.label = ?
 				)`)
-			args = append(args, label)
+			args = append(args, normalizeLabel(label))
+		}
+	}
+
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			whereClauses = append(whereClauses, "i.status = ?")
+		} else {
+			whereClauses = append(whereClauses, "i.status != ?")
 		}
+		args = append(args, types.StatusBlocked)
+	}
+
+	if filter.Approved != nil {
+		if *filter.Approved {
+			whereClauses = append(whereClauses, "i.approved_at IS NOT NULL")
+		} else {
+			whereClauses = append(whereClauses, "i.approved_at IS NULL")
+		}
+	}
+
+	if filter.Overdue {
+		whereClauses = append(whereClauses, "i.status != ? AND i.due_at IS NOT NULL AND julianday(i.due_at) < julianday(?)")
+		args = append(args, types.StatusClosed, s.now().UTC())
+	}
+
+	if filter.ParentID != nil {
+		if *filter.ParentID == "" {
+			whereClauses = append(whereClauses, `
+				NOT EXISTS (
+					SELECT 1 FROM dependencies d
+					WHERE d.issue_id = i.id AND d.type = ?
+				)`)
+			args = append(args, types.DepParentChild)
+		} else {
+			whereClauses = append(whereClauses, `
+				EXISTS (
+					SELECT 1 FROM dependencies d
+					WHERE d.issue_id = i.id AND d.type = ? AND d.depends_on_id = ?
+				)`)
+			args = append(args, types.DepParentChild, *filter.ParentID)
+		}
+	}
+
+	if filter.NoOpenBlockers {
+		whereClauses = append(whereClauses, `
+			NOT EXISTS (
+				SELECT 1 FROM dependencies d
+				JOIN issues blocker ON blocker.id = d.depends_on_id
+				WHERE d.issue_id = i.id AND d.type = ? AND blocker.status != ?
+			)`)
+		args = append(args, types.DepBlocks, types.StatusClosed)
+	}
+
+	// julianday compares instants, whatever offset the stored timestamp was written with
+	for _, bound := range []struct {
+		condition string
+		value     *time.Time
+	}{
+		{"julianday(i.created_at) >= julianday(?)", filter.CreatedAfter},
+		{"julianday(i.created_at) <= julianday(?)", filter.CreatedBefore},
+		{"julianday(i.updated_at) >= julianday(?)", filter.UpdatedAfter},
+		{"julianday(i.updated_at) <= julianday(?)", filter.UpdatedBefore},
+	} {
+		if bound.value != nil {
+			whereClauses = append(whereClauses, bound.condition)
+			args = append(args, bound.value.UTC())
+		}
+	}
+
+	if filter.HasFlag != nil {
+		whereClauses = append(whereClauses, `
+			EXISTS (
+				SELECT 1 FROM flags f
+				WHERE f.issue_id = i.id AND f.code = ? AND f.resolved_at IS NULL
+			)`)
+		args = append(args, *filter.HasFlag)
 	}
 
 	whereSQL := ""
@@ -574,20 +1223,29 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = fmt.Sprintf(" LIMIT %d", filter.Limit)
+	return whereSQL, args
+}
+
+// appendInClause adds a "column IN (...)" condition matching any of values, or
+// nothing when values is empty
+func appendInClause[T any](whereClauses []string, args []interface{}, column string, values []T) ([]string, []interface{}) {
+	if len(values) == 0 {
+		return whereClauses, args
+	}
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args = append(args, value)
 	}
+	return append(whereClauses, fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", "))), args
+}
 
-	querySQL := fmt.Sprintf(`
-		SELECT id, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at
-		FROM issues
-		%s
-		ORDER BY priority ASC, created_at DESC
-		%s
-	`, whereSQL, limitSQL)
+// SearchIssues finds issues matching query and filters
+func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	querySQL, args, err := s.searchIssuesSQL(issueColumns, query, filter)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := s.db.QueryContext(ctx, querySQL, args...)
 	if err != nil {
@@ -595,38 +1253,97 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	}
 	defer func() { _ = rows.Close() }()
 
-	var issues []*types.Issue
+	return s.scanIssues(rows)
+}
+
+// CountIssues returns how many issues SearchIssues would return for query and filter
+// without Limit and Offset, for rendering page counts
+func (s *SQLiteStorage) CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int, error) {
+	whereSQL, args := s.buildIssueWhere(query, filter)
+
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues i `+whereSQL, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+	return count, nil
+}
+
+// IterateIssues calls fn for each issue SearchIssues would return, in the same order,
+// reading rows one at a time so memory stays flat for large result sets.
+// Iteration stops at the first error fn returns, which IterateIssues returns.
+func (s *SQLiteStorage) IterateIssues(ctx context.Context, query string, filter types.IssueFilter, fn func(*types.Issue) error) error {
+	querySQL, args, err := s.searchIssuesSQL(issueColumns, query, filter)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
 	for rows.Next() {
-		var issue types.Issue
-		var closedAt sql.NullTime
-		var estimatedMinutes sql.NullInt64
-		var assignee sql.NullString
-
-		err := rows.Scan(
-			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
-			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt,
-		)
+		issue, err := s.scanIssue(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan issue: %w", err)
-		}
-
-		if closedAt.Valid {
-			issue.ClosedAt = &closedAt.Time
+			return fmt.Errorf("failed to scan issue: %w", err)
 		}
-		if estimatedMinutes.Valid {
-			mins := int(estimatedMinutes.Int64)
-			issue.EstimatedMinutes = &mins
+		if err := fn(issue); err != nil {
+			return err
 		}
-		if assignee.Valid {
-			issue.Assignee = assignee.String
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating issues: %w", err)
+	}
+	return nil
+}
+
+// searchIssuesSQL builds the query and arguments shared by SearchIssues, IterateIssues
+// and SearchIssuesWithCounts, selecting columns (issueColumns plus any extras)
+func (s *SQLiteStorage) searchIssuesSQL(columns, query string, filter types.IssueFilter) (string, []interface{}, error) {
+	whereSQL, args := s.buildIssueWhere(query, filter)
+
+	if filter.Offset < 0 {
+		return "", nil, fmt.Errorf("offset cannot be negative")
+	}
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		// SQLite only accepts OFFSET after a LIMIT; -1 means no limit
+		if limitSQL == "" {
+			limitSQL = " LIMIT -1"
 		}
+		limitSQL += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
 
-		issues = append(issues, &issue)
+	orderSQL := "priority ASC, created_at DESC"
+	if s.opts.defaultSort != "" {
+		orderSQL = s.opts.defaultSort
 	}
+	switch filter.SortBy {
+	case "":
+	case types.SortByVotes:
+		orderSQL = "(SELECT COUNT(*) FROM votes v WHERE v.issue_id = i.id) DESC, " + orderSQL
+	case types.SortByStatus:
+		// Statuses without a config row sort after configured ones
+		orderSQL = "COALESCE((SELECT sc.display_order FROM status_config sc WHERE sc.status = i.status), 2147483647) ASC, " + orderSQL
+	default:
+		return "", nil, fmt.Errorf("invalid sort field: %s", filter.SortBy)
+	}
+
+	// Pinned issues always come first, whatever the sort
+	querySQL := fmt.Sprintf(`
+		SELECT %s
+		FROM issues i
+		%s
+		ORDER BY i.pinned DESC, %s
+		%s
+	`, columns, whereSQL, orderSQL, limitSQL)
 
-	return issues, nil
+	return querySQL, args, nil
 }
 
 // GetConfig gets a configuration value from the config table
@@ -648,7 +1365,35 @@ func (s *SQLiteStorage) SetConfig(ctx context.Context, key, value string) error
 	return err
 }
 
-// Close closes the database connection
+// Stats returns connection pool statistics, for diagnosing leaks and tuning the pool
+func (s *SQLiteStorage) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// now returns the current time from the configured clock
+func (s *SQLiteStorage) now() time.Time {
+	if s.opts.clock != nil {
+		return s.opts.clock()
+	}
+	return time.Now()
+}
+
+// Close closes the database connection, first checkpointing the WAL into the main
+// database unless disabled with WithCheckpointOnClose(false). A failed checkpoint
+// is logged and does not prevent the close.
 func (s *SQLiteStorage) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+	if !s.opts.skipCheckpointOnClose {
+		if _, err := s.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+			s.opts.warnf("failed to checkpoint WAL on close: %v", err)
+		}
+	}
+	for _, stmt := range []*sql.Stmt{s.getIssueStmt, s.issueExistsStmt} {
+		if err := stmt.Close(); err != nil {
+			s.opts.warnf("failed to close prepared statement: %v", err)
+		}
+	}
 	return s.db.Close()
 }
@@ -3,8 +3,16 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/steveyegge/vc/internal/types"
@@ -139,6 +147,30 @@ func TestGetNextIDWithExistingIssues(t *testing.T) {
 	}
 }
 
+// TestGetNextIDNumericMax verifies getNextID compares ID numbers numerically, not as
+// text, where "bd-9" would sort after "bd-100"
+func TestGetNextIDNumericMax(t *testing.T) {
+	store := setupTestDB(t)
+
+	for _, id := range []string{"bd-9", "bd-100", "bd-23"} {
+		_, err := store.db.Exec(`
+			INSERT INTO issues (id, title, status, priority, issue_type)
+			VALUES (?, 'Test', 'open', 1, 'task')
+		`, id)
+		if err != nil {
+			t.Fatalf("Failed to insert issue: %v", err)
+		}
+	}
+
+	nextID, err := getNextID(store.db)
+	if err != nil {
+		t.Fatalf("getNextID failed: %v", err)
+	}
+	if nextID != 101 {
+		t.Errorf("Expected nextID=101, got %d", nextID)
+	}
+}
+
 // TestGetNextIDWithInvalidFormat verifies error handling for malformed IDs
 func TestGetNextIDWithInvalidFormat(t *testing.T) {
 	// Create temp database
@@ -594,6 +626,273 @@ func TestGetStatisticsWithEmptyDatabase(t *testing.T) {
 	}
 }
 
+// TestUpdateIssueAssigneeRequired verifies WithAssigneeRequired blocks moving an
+// unassigned issue into a guarded status until it has an assignee
+func TestUpdateIssueAssigneeRequired(t *testing.T) {
+	store := setupTestDB(t, WithAssigneeRequired(types.StatusInProgress))
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Needs an owner",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test")
+	if !errors.Is(err, ErrAssigneeRequired) {
+		t.Fatalf("Expected ErrAssigneeRequired, got %v", err)
+	}
+
+	// Other transitions are unaffected
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusBlocked)}, "test"); err != nil {
+		t.Fatalf("Expected transition to blocked to succeed, got %v", err)
+	}
+
+	// Assigning in the same update satisfies the rule
+	err = store.UpdateIssue(ctx, issue.ID, map[string]interface{}{
+		"status":   string(types.StatusInProgress),
+		"assignee": "alice",
+	}, "test")
+	if err != nil {
+		t.Fatalf("Expected transition with assignee to succeed, got %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Failed to get issue: %v", err)
+	}
+	if got.Status != types.StatusInProgress || got.Assignee != "alice" {
+		t.Errorf("Expected in_progress assigned to alice, got %s/%q", got.Status, got.Assignee)
+	}
+}
+
+// TestNewRejectsInvalidOptions verifies options are validated at New time
+func TestIssueValidationErrors(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Valid", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		field   string
+		want    error
+	}{
+		{"unknown field", map[string]interface{}{"id": "vc-99"}, "id", types.ErrInvalidField},
+		{"priority", map[string]interface{}{"priority": 5}, "priority", types.ErrInvalidPriority},
+		{"status", map[string]interface{}{"status": "done"}, "status", types.ErrInvalidStatus},
+		{"issue type", map[string]interface{}{"issue_type": "story"}, "issue_type", types.ErrInvalidIssueType},
+		{"title", map[string]interface{}{"title": ""}, "title", types.ErrTitleLength},
+		{"estimate", map[string]interface{}{"estimate_max_minutes": -1}, "estimate_max_minutes", types.ErrInvalidEstimate},
+		{"estimate range", map[string]interface{}{"estimate_min_minutes": 60, "estimate_max_minutes": 30}, "estimate_min_minutes", types.ErrInvalidEstimate},
+	}
+	for _, tt := range tests {
+		t.Run("update "+tt.name, func(t *testing.T) {
+			err := store.UpdateIssue(ctx, issue.ID, tt.updates, "test")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			var verr *types.ValidationError
+			if !errors.As(err, &verr) || verr.Field != tt.field {
+				t.Errorf("Expected ValidationError for field %q, got %v", tt.field, err)
+			}
+		})
+	}
+
+	// CreateIssue wraps the error from Issue.Validate
+	bad := &types.Issue{Title: "Bad", Status: types.StatusOpen, Priority: 8, IssueType: types.TypeTask}
+	err := store.CreateIssue(ctx, bad, "test")
+	var verr *types.ValidationError
+	if !errors.Is(err, types.ErrInvalidPriority) || !errors.As(err, &verr) || verr.Value != 8 {
+		t.Errorf("Expected wrapped ErrInvalidPriority with value 8, got %v", err)
+	}
+
+	// Other input checks are validation errors too
+	if _, err := store.AddCommentWithID(ctx, issue.ID, "test", "  "); !errors.Is(err, types.ErrValidation) {
+		t.Errorf("Expected ErrValidation for an empty comment, got %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "", "test"); !errors.Is(err, types.ErrInvalidField) {
+		t.Errorf("Expected ErrInvalidField for an empty label, got %v", err)
+	}
+}
+
+func TestWithNotFoundErrors(t *testing.T) {
+	ctx := context.Background()
+
+	issue, err := setupTestDB(t).GetIssue(ctx, "vc-9999")
+	if issue != nil || err != nil {
+		t.Errorf("Expected nil issue and error by default, got %v, %v", issue, err)
+	}
+
+	store := setupTestDB(t, WithNotFoundErrors())
+	if _, err := store.GetIssue(ctx, "vc-9999"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound from GetIssue, got %v", err)
+	}
+	if _, err := store.GetIssueTracked(ctx, "vc-9999", "test"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound from GetIssueTracked, got %v", err)
+	}
+
+	// Methods that already failed on a missing issue now wrap the same sentinel
+	if err := store.UpdateIssue(ctx, "vc-9999", map[string]interface{}{"priority": 1}, "test"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound from UpdateIssue, got %v", err)
+	}
+	if err := store.CloseIssue(ctx, "vc-9999", "done", "test"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound from CloseIssue, got %v", err)
+	}
+
+	created := &types.Issue{Title: "Present", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, created, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if got, err := store.GetIssue(ctx, created.ID); err != nil || got == nil {
+		t.Errorf("Expected existing issue, got %v, %v", got, err)
+	}
+}
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test-*.db")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer func() { _ = os.Remove(tmpfile.Name()) }()
+	_ = tmpfile.Close()
+
+	if _, err := New(tmpfile.Name(), WithAssigneeRequired("bogus")); err == nil {
+		t.Error("Expected New to reject an invalid status")
+	}
+	if _, err := New(tmpfile.Name(), WithDefaultIssueType("story")); err == nil {
+		t.Error("Expected New to reject an invalid default issue type")
+	}
+	if _, err := New(tmpfile.Name(), WithDefaultSort("description", "asc")); err == nil {
+		t.Error("Expected New to reject a non-sortable default sort field")
+	}
+	if _, err := New(tmpfile.Name(), WithDefaultSort("title", "sideways")); err == nil {
+		t.Error("Expected New to reject an invalid default sort direction")
+	}
+	if _, err := New(tmpfile.Name(), WithMaxPriority(-1)); err == nil {
+		t.Error("Expected New to reject a negative max priority")
+	}
+	if _, err := New(tmpfile.Name(), WithTypeDefaultPriorities(map[types.IssueType]int{"story": 1})); err == nil {
+		t.Error("Expected New to reject a default priority for an invalid type")
+	}
+	if _, err := New(tmpfile.Name(), WithTypeDefaultPriorities(map[types.IssueType]int{types.TypeBug: 7})); err == nil {
+		t.Error("Expected New to reject an out-of-range default priority")
+	}
+}
+
+func TestCreateIssueDefaultIssueType(t *testing.T) {
+	store := setupTestDB(t, WithDefaultIssueType(types.TypeBug))
+	ctx := context.Background()
+
+	untyped := &types.Issue{Title: "No type given", Status: types.StatusOpen, Priority: 2}
+	if err := store.CreateIssue(ctx, untyped, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, untyped.ID)
+	if got.IssueType != types.TypeBug {
+		t.Errorf("Expected default type %s, got %s", types.TypeBug, got.IssueType)
+	}
+
+	typed := &types.Issue{Title: "Explicit type", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	if err := store.CreateIssue(ctx, typed, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, typed.ID)
+	if got.IssueType != types.TypeFeature {
+		t.Errorf("Expected explicit type to be kept, got %s", got.IssueType)
+	}
+
+	// Without the option an empty type is still rejected
+	plain := setupTestDB(t)
+	if err := plain.CreateIssue(ctx, &types.Issue{Title: "No type", Status: types.StatusOpen, Priority: 2}, "test"); err == nil {
+		t.Error("Expected empty issue type to be rejected by default")
+	}
+}
+
+func TestCloseCheckpointsWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vc.db")
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	issue := &types.Issue{Title: "Write to WAL", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if _, err := os.Stat(path + "-wal"); err != nil {
+		t.Fatalf("Expected -wal file while open: %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(path + "-wal"); !os.IsNotExist(err) {
+		t.Errorf("Expected -wal file to be removed after Close, got %v", err)
+	}
+
+	// The write survived the checkpoint
+	reopened, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+	got, err := reopened.GetIssue(context.Background(), issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("Expected issue after reopen, got %v, %v", got, err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	store := setupTestDB(t, WithConnMaxIdleTime(time.Minute), WithConnMaxLifetime(time.Hour))
+	ctx := context.Background()
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+
+	stats := store.Stats()
+	if stats.OpenConnections < 1 {
+		t.Errorf("Expected at least one open connection after a query, got %d", stats.OpenConnections)
+	}
+	if stats.InUse != 0 {
+		t.Errorf("Expected no connections in use after the query finished, got %d", stats.InUse)
+	}
+}
+
+func TestSearchIssuesDefaultSort(t *testing.T) {
+	store := setupTestDB(t, WithDefaultSort("title", "asc"))
+	ctx := context.Background()
+
+	for i, title := range []string{"Charlie", "Alpha", "Bravo"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: i, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	var titles []string
+	for _, issue := range results {
+		titles = append(titles, issue.Title)
+	}
+	want := []string{"Alpha", "Bravo", "Charlie"}
+	if strings.Join(titles, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected titles %v, got %v", want, titles)
+	}
+}
+
 // contains checks if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -608,3 +907,736 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestUpdateIssueMarshalError(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Marshal", Notes: "original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// A channel cannot be encoded as event data; the error must surface
+	// instead of an empty event being recorded
+	_, err := store.UpdateIssueChanged(ctx, issue.ID, map[string]interface{}{"notes": make(chan int)}, "test")
+	var unsupported *json.UnsupportedTypeError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Expected json.UnsupportedTypeError, got %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Notes != "original" {
+		t.Errorf("Expected issue to be left unchanged, got notes %q", got.Notes)
+	}
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected only the creation event, got %d events", len(events))
+	}
+}
+
+func TestCreateIssueAssignToReporter(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Mine", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask, Assignee: "bob"}
+	if err := store.CreateIssueWithOptions(ctx, issue, types.CreateOptions{AssignToReporter: true}, "agent-1"); err != nil {
+		t.Fatalf("CreateIssueWithOptions failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Assignee != "agent-1" {
+		t.Errorf("Expected assignee to be the reporter, got %q", got.Assignee)
+	}
+
+	other := &types.Issue{Title: "Theirs", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "bob"}
+	if err := store.CreateIssueWithOptions(ctx, other, types.CreateOptions{}, "agent-1"); err != nil {
+		t.Fatalf("CreateIssueWithOptions failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, other.ID); got.Assignee != "bob" {
+		t.Errorf("Expected explicit assignee kept without the option, got %q", got.Assignee)
+	}
+}
+
+// TestCreateIssueAfterOutOfBandInsert verifies that issues inserted by another
+// process, bypassing the counter, don't cause ID conflicts: the counter is resynced
+// with the highest existing ID inside every create transaction
+func TestCreateIssueAfterOutOfBandInsert(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	first := &types.Issue{Title: "First", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, first, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	prefix := strings.TrimSuffix(first.ID, "-1")
+
+	// Another writer inserts directly, leaving issue_counters behind
+	_, err := store.db.Exec(`
+		INSERT INTO issues (id, title, status, priority, issue_type)
+		VALUES (?, 'External', 'open', 2, 'task')
+	`, prefix+"-41")
+	if err != nil {
+		t.Fatalf("Out-of-band insert failed: %v", err)
+	}
+
+	next := &types.Issue{Title: "Next", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, next, "test"); err != nil {
+		t.Fatalf("CreateIssue after out-of-band insert failed: %v", err)
+	}
+	if next.ID != prefix+"-42" {
+		t.Errorf("Expected %s-42 after the external insert, got %s", prefix, next.ID)
+	}
+}
+
+func TestReopenIssue(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Regressed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.ReopenIssue(ctx, issue.ID, "still broken", "test"); !errors.Is(err, ErrNotClosed) {
+		t.Errorf("Expected ErrNotClosed for an open issue, got %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "fixed", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.ReopenIssue(ctx, issue.ID, "still broken", "qa"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen || got.ClosedAt != nil {
+		t.Errorf("Expected open with no closed_at, got %s and %v", got.Status, got.ClosedAt)
+	}
+
+	events, err := store.GetEventTimeline(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetEventTimeline failed: %v", err)
+	}
+	last := events[len(events)-1]
+	if last.EventType != types.EventReopened || last.Actor != "qa" || last.Comment == nil || *last.Comment != "still broken" {
+		t.Errorf("Expected a reopened event by qa with the reason, got %+v", last)
+	}
+
+	if err := store.ReopenIssue(ctx, "vc-9999", "", "test"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
+
+func TestCreateIssues(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var batch []*types.Issue
+	for i := 0; i < 50; i++ {
+		batch = append(batch, &types.Issue{Title: fmt.Sprintf("Imported %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask})
+	}
+	ids, err := store.CreateIssues(ctx, batch, "importer")
+	if err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	if len(ids) != len(batch) {
+		t.Fatalf("Expected %d IDs, got %d", len(batch), len(ids))
+	}
+	first, _ := strconv.Atoi(ids[0][strings.LastIndex(ids[0], "-")+1:])
+	for i, id := range ids {
+		if id != batch[i].ID || id != fmt.Sprintf("%s%d", store.issuePrefix, first+i) {
+			t.Fatalf("Expected consecutive IDs in order, got %v", ids)
+		}
+		got, err := store.GetIssue(ctx, id)
+		if err != nil || got == nil || got.Title != batch[i].Title {
+			t.Fatalf("Expected %s to be created, got %v (%v)", id, got, err)
+		}
+		events, _ := store.GetEventTimeline(ctx, id)
+		if len(events) != 1 || events[0].EventType != types.EventCreated || events[0].Actor != "importer" {
+			t.Errorf("Expected one creation event for %s, got %+v", id, events)
+		}
+	}
+
+	// One invalid issue rolls back the whole batch
+	bad := []*types.Issue{
+		{Title: "Fine", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{Title: "", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	if _, err := store.CreateIssues(ctx, bad, "importer"); err == nil {
+		t.Fatal("Expected a validation error")
+	}
+	// A duplicate ID fails at insert time, after earlier issues were written
+	dup := []*types.Issue{
+		{Title: "Fine", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: ids[0], Title: "Duplicate", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	if _, err := store.CreateIssues(ctx, dup, "importer"); err == nil {
+		t.Fatal("Expected an error for a duplicate ID")
+	}
+	if dup[0].ID != "" {
+		t.Errorf("Expected the rolled-back issue's generated ID to be cleared, got %s", dup[0].ID)
+	}
+	count, err := store.CountIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("CountIssues failed: %v", err)
+	}
+	if count != len(batch) {
+		t.Errorf("Expected failed batches to create nothing, got %d issues", count)
+	}
+}
+
+func TestUnauditedFields(t *testing.T) {
+	store := setupTestDB(t, WithUnauditedFields("notes"))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Audited", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	countEvents := func() int {
+		t.Helper()
+		events, err := store.GetEventTimeline(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetEventTimeline failed: %v", err)
+		}
+		return len(events)
+	}
+	before := countEvents()
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "scratch"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Notes != "scratch" {
+		t.Errorf("Expected the unaudited field to be written, got %q", got.Notes)
+	}
+	if n := countEvents(); n != before {
+		t.Errorf("Expected no event for an unaudited field, got %d new", n-before)
+	}
+
+	// A mixed update records only the audited fields
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "more", "priority": 1}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	events, _ := store.GetEventTimeline(ctx, issue.ID)
+	last := events[len(events)-1]
+	if len(events) != before+1 || last.NewValue == nil || *last.NewValue != `{"priority":1}` {
+		t.Errorf("Expected one event with only the priority, got %d events, last %v", len(events)-before, last.NewValue)
+	}
+
+	for _, field := range []string{"status", "bogus"} {
+		o := options{}
+		WithUnauditedFields(field)(&o)
+		if err := o.validate(); err == nil {
+			t.Errorf("Expected %s to be rejected", field)
+		}
+	}
+}
+
+func TestUpdateIssueIfUnchanged(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Contended", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Two agents read the same version
+	read, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	if err := store.UpdateIssueIfUnchanged(ctx, issue.ID, map[string]interface{}{"priority": 1}, read.UpdatedAt, "agent-a"); err != nil {
+		t.Fatalf("First guarded update failed: %v", err)
+	}
+	err = store.UpdateIssueIfUnchanged(ctx, issue.ID, map[string]interface{}{"priority": 3}, read.UpdatedAt, "agent-b")
+	if !errors.Is(err, ErrConcurrentModification) {
+		t.Fatalf("Expected ErrConcurrentModification for the stale write, got %v", err)
+	}
+
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Priority != 1 {
+		t.Errorf("Expected the stale write to be rejected, got priority %d", got.Priority)
+	}
+
+	// Re-reading and retrying succeeds
+	if err := store.UpdateIssueIfUnchanged(ctx, issue.ID, map[string]interface{}{"priority": 3}, got.UpdatedAt, "agent-b"); err != nil {
+		t.Errorf("Retry with fresh updated_at failed: %v", err)
+	}
+
+	if err := store.UpdateIssueIfUnchanged(ctx, "vc-9999", map[string]interface{}{"priority": 1}, time.Now(), "test"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
+
+func TestSearchIssuesOffsetAndCount(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Bug %d", i), Status: types.StatusOpen, Priority: i % 5, IssueType: types.TypeBug}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	other := &types.Issue{Title: "Chore", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeChore}
+	if err := store.CreateIssue(ctx, other, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	bug := types.TypeBug
+	filter := types.IssueFilter{IssueType: &bug, Limit: 2}
+
+	count, err := store.CountIssues(ctx, "", filter)
+	if err != nil {
+		t.Fatalf("CountIssues failed: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 matching bugs regardless of limit, got %d", count)
+	}
+
+	// Paging with the same filter visits every bug exactly once, in order
+	var titles []string
+	for filter.Offset = 0; filter.Offset < count; filter.Offset += filter.Limit {
+		page, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		for _, issue := range page {
+			titles = append(titles, issue.Title)
+		}
+	}
+	want := []string{"Bug 0", "Bug 1", "Bug 2", "Bug 3", "Bug 4"}
+	if strings.Join(titles, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected pages to cover %v, got %v", want, titles)
+	}
+
+	// Offset without a limit skips the first results
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{IssueType: &bug, Offset: 3})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected 2 issues after offset 3, got %d", len(all))
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Offset: -1}); err == nil {
+		t.Error("Expected error for negative offset")
+	}
+}
+
+func TestSearchIssuesMultiValueFilters(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	for _, spec := range []struct {
+		title     string
+		status    types.Status
+		priority  int
+		issueType types.IssueType
+	}{
+		{"Open bug", types.StatusOpen, 0, types.TypeBug},
+		{"Working task", types.StatusInProgress, 1, types.TypeTask},
+		{"Blocked chore", types.StatusBlocked, 2, types.TypeChore},
+		{"Closed bug", types.StatusClosed, 3, types.TypeBug},
+	} {
+		issue := &types.Issue{Title: spec.title, Status: spec.status, Priority: spec.priority, IssueType: spec.issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	titles := func(filter types.IssueFilter) string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.Title)
+		}
+		return strings.Join(got, ",")
+	}
+
+	active := []types.Status{types.StatusOpen, types.StatusInProgress}
+	if got := titles(types.IssueFilter{Statuses: active}); got != "Open bug,Working task" {
+		t.Errorf("Expected active issues, got %s", got)
+	}
+
+	// The single Status field merges with Statuses
+	blocked := types.StatusBlocked
+	if got := titles(types.IssueFilter{Statuses: active, Status: &blocked}); got != "Open bug,Working task,Blocked chore" {
+		t.Errorf("Expected active and blocked issues, got %s", got)
+	}
+	if got := titles(types.IssueFilter{Status: &blocked}); got != "Blocked chore" {
+		t.Errorf("Expected the single Status filter to keep working, got %s", got)
+	}
+	if len(active) != 2 {
+		t.Errorf("Expected the caller's slice to be left alone, got %v", active)
+	}
+
+	if got := titles(types.IssueFilter{Priorities: []int{1, 3}}); got != "Working task,Closed bug" {
+		t.Errorf("Expected priorities 1 and 3, got %s", got)
+	}
+	if got := titles(types.IssueFilter{IssueTypes: []types.IssueType{types.TypeBug, types.TypeChore}, Statuses: active}); got != "Open bug" {
+		t.Errorf("Expected active bugs and chores, got %s", got)
+	}
+}
+
+func TestCreateIssueTypeDefaultPriority(t *testing.T) {
+	store := setupTestDB(t, WithTypeDefaultPriorities(map[types.IssueType]int{
+		types.TypeBug:   1,
+		types.TypeChore: 3,
+	}))
+	ctx := context.Background()
+
+	for issueType, want := range map[types.IssueType]int{types.TypeBug: 1, types.TypeChore: 3} {
+		issue := &types.Issue{Title: "Defaulted " + string(issueType), Status: types.StatusOpen, Priority: types.PriorityUnset, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		got, _ := store.GetIssue(ctx, issue.ID)
+		if got.Priority != want {
+			t.Errorf("Expected %s to default to priority %d, got %d", issueType, want, got.Priority)
+		}
+	}
+
+	// An explicit priority, including P0, is kept
+	urgent := &types.Issue{Title: "Urgent bug", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, urgent, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, urgent.ID); got.Priority != 0 {
+		t.Errorf("Expected explicit priority 0 to be kept, got %d", got.Priority)
+	}
+
+	// Types without a default still need a priority
+	feature := &types.Issue{Title: "Feature", Status: types.StatusOpen, Priority: types.PriorityUnset, IssueType: types.TypeFeature}
+	if err := store.CreateIssue(ctx, feature, "test"); !errors.Is(err, types.ErrInvalidPriority) {
+		t.Errorf("Expected ErrInvalidPriority for a type without a default, got %v", err)
+	}
+}
+
+func TestSearchIssuesDateRange(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	// Timestamps written with different offsets compare as instants
+	east := time.FixedZone("UTC+5", 5*60*60)
+	base := time.Date(2025, 5, 5, 12, 0, 0, 0, time.UTC)
+	for i, created := range []time.Time{
+		base.Add(-48 * time.Hour),
+		base.In(east),
+		base.Add(48 * time.Hour),
+	} {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		updated := created.Add(time.Hour)
+		if _, err := store.db.Exec(`UPDATE issues SET created_at = ?, updated_at = ? WHERE id = ?`, created, updated, issue.ID); err != nil {
+			t.Fatalf("Failed to set timestamps: %v", err)
+		}
+	}
+
+	titles := func(filter types.IssueFilter) string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.Title)
+		}
+		sort.Strings(got)
+		return strings.Join(got, ",")
+	}
+
+	// Both bounds are inclusive
+	after, before := base, base.Add(48*time.Hour)
+	if got := titles(types.IssueFilter{CreatedAfter: &after, CreatedBefore: &before}); got != "Issue 1,Issue 2" {
+		t.Errorf("Expected issues created in [base, base+48h], got %s", got)
+	}
+	localBefore := base.In(time.FixedZone("UTC-8", -8*60*60))
+	if got := titles(types.IssueFilter{CreatedBefore: &localBefore}); got != "Issue 0,Issue 1" {
+		t.Errorf("Expected a bound in another zone to be compared as an instant, got %s", got)
+	}
+
+	updatedAfter := base.Add(time.Hour)
+	updatedBefore := base.Add(2 * time.Hour)
+	if got := titles(types.IssueFilter{UpdatedAfter: &updatedAfter, UpdatedBefore: &updatedBefore}); got != "Issue 1" {
+		t.Errorf("Expected only the issue updated at base+1h, got %s", got)
+	}
+
+	// Combines with other filters
+	closed := types.StatusClosed
+	if got := titles(types.IssueFilter{CreatedAfter: &after, Status: &closed}); got != "" {
+		t.Errorf("Expected no closed issues, got %s", got)
+	}
+}
+
+func TestWithIDPrefix(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "bd.db")
+
+	// A database created with its filename prefix keeps working when the prefix matches
+	legacy, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	first := &types.Issue{Title: "Legacy", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := legacy.CreateIssue(ctx, first, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	_ = legacy.Close()
+
+	store, err := New(path, WithIDPrefix("bd"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	second := &types.Issue{Title: "Continued", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, second, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if first.ID != "bd-1" || second.ID != "bd-2" {
+		t.Errorf("Expected bd-1 then bd-2, got %s and %s", first.ID, second.ID)
+	}
+	_ = store.Close()
+
+	// A different prefix starts its own sequence in the same database
+	store, err = New(path, WithIDPrefix("web-"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	web := &types.Issue{Title: "Web", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, web, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if web.ID != "web-1" {
+		t.Errorf("Expected web-1, got %s", web.ID)
+	}
+
+	for _, bad := range []string{"api2", "my-api", "9lives"} {
+		if _, err := New(filepath.Join(t.TempDir(), "x.db"), WithIDPrefix(bad)); err == nil {
+			t.Errorf("Expected New to reject prefix %q", bad)
+		}
+	}
+	if _, err := New(filepath.Join(t.TempDir(), "x.db"), WithIDPrefix("web"), WithProjectScope("api")); err == nil {
+		t.Error("Expected New to reject an ID prefix combined with a project scope")
+	}
+}
+
+func TestDueAtAndOverdueFilter(t *testing.T) {
+	store := setupTestDB(t)
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	past := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+	future := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	create := func(title string, dueAt *time.Time) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueAt: dueAt}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	overdue := create("Overdue", &past)
+	upcoming := create("Upcoming", &future)
+	undated := create("No due date", nil)
+	closed := create("Closed late", &past)
+	if err := store.CloseIssue(ctx, closed.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, overdue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.DueAt == nil || !got.DueAt.Equal(past) {
+		t.Errorf("DueAt = %v, want %v", got.DueAt, past)
+	}
+	if got, _ := store.GetIssue(ctx, undated.ID); got.DueAt != nil {
+		t.Errorf("expected no due date, got %v", got.DueAt)
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Overdue: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != overdue.ID {
+		t.Fatalf("expected only %s to be overdue, got %d issues", overdue.ID, len(results))
+	}
+	if results[0].DueAt == nil || !results[0].DueAt.Equal(past) {
+		t.Errorf("search DueAt = %v, want %v", results[0].DueAt, past)
+	}
+
+	// Moving the upcoming issue's due date into the past makes it overdue
+	if err := store.UpdateIssue(ctx, upcoming.ID, map[string]interface{}{"due_at": past}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	// Clearing the due date stores NULL
+	if err := store.UpdateIssue(ctx, overdue.ID, map[string]interface{}{"due_at": nil}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, overdue.ID); got.DueAt != nil {
+		t.Errorf("expected cleared due date, got %v", got.DueAt)
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{Overdue: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != upcoming.ID {
+		t.Errorf("expected only %s to be overdue, got %d issues", upcoming.ID, len(results))
+	}
+
+	// Overdue is judged against the store's clock, not the wall clock
+	clock := time.Date(2020, 3, 1, 12, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	clocked := setupTestDB(t, WithClock(func() time.Time { return clock }))
+	due := time.Date(2020, 3, 1, 15, 0, 0, 0, time.UTC)
+	issue := &types.Issue{Title: "Due this afternoon", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueAt: &due}
+	if err := clocked.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	// 12:00 at UTC-5 is 17:00 UTC, two hours past due
+	results, err = clocked.SearchIssues(ctx, "", types.IssueFilter{Overdue: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issue.ID {
+		t.Errorf("expected %s to be overdue at %v, got %d issues", issue.ID, clock, len(results))
+	}
+	clock = time.Date(2020, 3, 1, 9, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	results, err = clocked.SearchIssues(ctx, "", types.IssueFilter{Overdue: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected nothing overdue at %v, got %d issues", clock, len(results))
+	}
+}
+
+// BenchmarkGetIssue compares 10k GetIssue lookups through the prepared statement
+// with the same query prepared on every call, as GetIssue did before.
+func BenchmarkGetIssue(b *testing.B) {
+	ctx := context.Background()
+	store, err := New(filepath.Join(b.TempDir(), "bench.db"))
+	if err != nil {
+		b.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	var ids []string
+	for n := 0; n < 100; n++ {
+		issue := &types.Issue{Title: "Lookup", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "bench"); err != nil {
+			b.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	const gets = 10000
+	b.Run("prepared", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for n := 0; n < gets; n++ {
+				if _, err := store.GetIssue(ctx, ids[n%len(ids)]); err != nil {
+					b.Fatalf("GetIssue failed: %v", err)
+				}
+			}
+		}
+	})
+	b.Run("unprepared", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for n := 0; n < gets; n++ {
+				id := ids[n%len(ids)]
+				row := store.db.QueryRowContext(ctx, getIssueSQL, id, id, "", "", id)
+				if _, err := store.scanIssue(row); err != nil {
+					b.Fatalf("scanIssue failed: %v", err)
+				}
+			}
+		}
+	})
+}
+
+func TestApprovalFieldsRoundTrip(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	approvedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	created := &types.Issue{
+		Title: "Approved at creation", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		ApprovedAt: &approvedAt, ApprovedBy: "alice",
+	}
+	updated := &types.Issue{Title: "Approved later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{created, updated} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.UpdateIssue(ctx, updated.ID, map[string]interface{}{
+		"approved_at": approvedAt,
+		"approved_by": "bob",
+	}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	want := map[string]string{created.ID: "alice", updated.ID: "bob"}
+	check := func(source string, issue *types.Issue) {
+		t.Helper()
+		if issue.ApprovedAt == nil || !issue.ApprovedAt.Equal(approvedAt) {
+			t.Errorf("%s: expected %s approved at %v, got %v", source, issue.ID, approvedAt, issue.ApprovedAt)
+		}
+		if issue.ApprovedBy != want[issue.ID] {
+			t.Errorf("%s: expected %s approved by %q, got %q", source, issue.ID, want[issue.ID], issue.ApprovedBy)
+		}
+	}
+
+	for id := range want {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		check("GetIssue", issue)
+	}
+
+	issues, err := store.SearchIssues(ctx, "Approved", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	for _, issue := range issues {
+		check("SearchIssues", issue)
+	}
+
+	// Unapproved issues leave the fields empty
+	plain := &types.Issue{Title: "Plain", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, plain, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, plain.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.ApprovedAt != nil || got.ApprovedBy != "" {
+		t.Errorf("Expected no approval, got %v by %q", got.ApprovedAt, got.ApprovedBy)
+	}
+}
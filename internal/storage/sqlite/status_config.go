@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// GetStatusConfig returns the display configuration of all statuses in board order
+func (s *SQLiteStorage) GetStatusConfig(ctx context.Context) ([]*types.StatusConfig, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT status, display_order, category
+		FROM status_config
+		ORDER BY display_order ASC, status ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status config: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var configs []*types.StatusConfig
+	for rows.Next() {
+		var cfg types.StatusConfig
+		if err := rows.Scan(&cfg.Status, &cfg.DisplayOrder, &cfg.Category); err != nil {
+			return nil, fmt.Errorf("failed to scan status config: %w", err)
+		}
+		configs = append(configs, &cfg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status config: %w", err)
+	}
+
+	return configs, nil
+}
+
+// SetStatusConfig creates or replaces the display configuration for a status
+func (s *SQLiteStorage) SetStatusConfig(ctx context.Context, cfg *types.StatusConfig) error {
+	if !cfg.Status.IsValid() {
+		return fmt.Errorf("invalid status: %s", cfg.Status)
+	}
+	if !cfg.Category.IsValid() {
+		return fmt.Errorf("invalid status category: %s", cfg.Category)
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO status_config (status, display_order, category) VALUES (?, ?, ?)
+		ON CONFLICT (status) DO UPDATE SET
+			display_order = excluded.display_order,
+			category = excluded.category
+	`, cfg.Status, cfg.DisplayOrder, cfg.Category)
+	if err != nil {
+		return fmt.Errorf("failed to set status config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestStatusConfigDefaults(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	configs, err := store.GetStatusConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetStatusConfig failed: %v", err)
+	}
+
+	want := []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed}
+	if len(configs) != len(want) {
+		t.Fatalf("Expected %d seeded statuses, got %d", len(want), len(configs))
+	}
+	for i, status := range want {
+		if configs[i].Status != status {
+			t.Errorf("Position %d: expected %s, got %s", i, status, configs[i].Status)
+		}
+	}
+	if configs[3].Category != types.StatusCategoryDone {
+		t.Errorf("Expected closed to be in the done category, got %s", configs[3].Category)
+	}
+
+	if err := store.SetStatusConfig(ctx, &types.StatusConfig{Status: types.StatusOpen, Category: "bogus"}); err == nil {
+		t.Error("Expected error for invalid category")
+	}
+}
+
+func TestSearchIssuesSortByStatusConfig(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	byStatus := make(map[types.Status]string)
+	for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusClosed} {
+		issue := &types.Issue{Title: string(status), Status: status, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		byStatus[status] = issue.ID
+	}
+
+	// Reorder the board: in_progress first, then closed, then open
+	reorder := []*types.StatusConfig{
+		{Status: types.StatusInProgress, DisplayOrder: 0, Category: types.StatusCategoryInProgress},
+		{Status: types.StatusClosed, DisplayOrder: 1, Category: types.StatusCategoryDone},
+		{Status: types.StatusOpen, DisplayOrder: 2, Category: types.StatusCategoryTodo},
+	}
+	for _, cfg := range reorder {
+		if err := store.SetStatusConfig(ctx, cfg); err != nil {
+			t.Fatalf("SetStatusConfig failed: %v", err)
+		}
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: types.SortByStatus})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, cfg := range reorder {
+		if results[i].ID != byStatus[cfg.Status] {
+			t.Errorf("Position %d: expected %s issue, got %s", i, cfg.Status, results[i].Status)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// placeholderPattern matches {{name}}, allowing spaces inside the braces
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// templateVariables returns the values of the supported template placeholders for
+// an issue being created at now:
+//
+//	{{assignee}}  the issue's assignee (empty if unassigned)
+//	{{date}}      the creation date, YYYY-MM-DD
+//	{{datetime}}  the creation time, RFC 3339
+//	{{priority}}  the issue's priority number
+//	{{type}}      the issue's type
+func templateVariables(issue *types.Issue, now time.Time) map[string]string {
+	return map[string]string{
+		"assignee": issue.Assignee,
+		"date":     now.Format("2006-01-02"),
+		"datetime": now.Format(time.RFC3339),
+		"priority": strconv.Itoa(issue.Priority),
+		"type":     string(issue.IssueType),
+	}
+}
+
+// expandPlaceholders substitutes the known placeholders in text. Unknown placeholders
+// are left untouched, or rejected with ErrUnknownPlaceholder when strict is set.
+func expandPlaceholders(text string, vars map[string]string, strict bool) (string, error) {
+	var unknown string
+	expanded := placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		if unknown == "" {
+			unknown = name
+		}
+		return match
+	})
+	if strict && unknown != "" {
+		return "", fmt.Errorf("%w: {{%s}}", ErrUnknownPlaceholder, unknown)
+	}
+	return expanded, nil
+}
+
+// CreateIssueFromTemplate creates an open issue from tmpl, assigned to assignee,
+// with placeholders in the template's text fields substituted from the new issue
+// and the storage clock.
+func (s *SQLiteStorage) CreateIssueFromTemplate(ctx context.Context, tmpl *types.IssueTemplate, assignee string, actor string) (*types.Issue, error) {
+	issue := &types.Issue{
+		Status:    types.StatusOpen,
+		Priority:  tmpl.Priority,
+		IssueType: tmpl.IssueType,
+		Assignee:  assignee,
+	}
+	// Apply defaults first so {{type}} reflects WithDefaultIssueType
+	s.applyCreateDefaults(issue)
+	vars := templateVariables(issue, s.now())
+
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&issue.Title, tmpl.Title},
+		{&issue.Description, tmpl.Description},
+		{&issue.Design, tmpl.Design},
+		{&issue.AcceptanceCriteria, tmpl.AcceptanceCriteria},
+		{&issue.Notes, tmpl.Notes},
+	} {
+		expanded, err := expandPlaceholders(field.src, vars, tmpl.StrictPlaceholders)
+		if err != nil {
+			return nil, err
+		}
+		*field.dst = expanded
+	}
+
+	if err := s.CreateIssue(ctx, issue, actor); err != nil {
+		return nil, fmt.Errorf("failed to create issue from template: %w", err)
+	}
+	return issue, nil
+}
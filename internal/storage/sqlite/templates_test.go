@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestCreateIssueFromTemplate(t *testing.T) {
+	now := time.Date(2025, 7, 4, 15, 30, 0, 0, time.UTC)
+	store := setupTestDB(t, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	tmpl := &types.IssueTemplate{
+		Title:       "Weekly review {{date}}",
+		Description: "Owner: {{ assignee }}, type {{type}}, unknown {{sprint}}",
+		Priority:    2,
+		IssueType:   types.TypeChore,
+	}
+	issue, err := store.CreateIssueFromTemplate(ctx, tmpl, "alice", "test")
+	if err != nil {
+		t.Fatalf("CreateIssueFromTemplate failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Title != "Weekly review 2025-07-04" {
+		t.Errorf("Expected date substituted in title, got %q", got.Title)
+	}
+	if got.Description != "Owner: alice, type chore, unknown {{sprint}}" {
+		t.Errorf("Expected known placeholders substituted and unknown left alone, got %q", got.Description)
+	}
+	if got.Assignee != "alice" || got.Status != types.StatusOpen {
+		t.Errorf("Expected open issue assigned to alice, got %s/%q", got.Status, got.Assignee)
+	}
+
+	tmpl.StrictPlaceholders = true
+	if _, err := store.CreateIssueFromTemplate(ctx, tmpl, "alice", "test"); !errors.Is(err, ErrUnknownPlaceholder) {
+		t.Errorf("Expected ErrUnknownPlaceholder in strict mode, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// TouchIssue marks an issue as recently active without changing any field: it bumps
+// updated_at to now, resetting staleness timers, and records an EventTouched
+func (s *SQLiteStorage) TouchIssue(ctx context.Context, id, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues SET updated_at = ?
+		WHERE id = ? AND (? = '' OR project_id = ?)
+	`, now, id, s.opts.project, s.opts.project)
+	if err != nil {
+		return fmt.Errorf("failed to touch issue: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issue %s: %w", id, ErrIssueNotFound)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, created_at)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventTouched, actor, now)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	return tx.Commit()
+}
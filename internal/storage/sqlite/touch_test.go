@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestTouchIssue(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	store := setupTestDB(t, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Touch me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	now = now.Add(72 * time.Hour)
+	if err := store.TouchIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("TouchIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !got.UpdatedAt.Equal(now) {
+		t.Errorf("Expected updated_at %v, got %v", now, got.UpdatedAt)
+	}
+	if got.Title != issue.Title || got.Priority != issue.Priority {
+		t.Errorf("Expected fields unchanged, got %+v", got)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	touched := 0
+	for _, e := range events {
+		if e.EventType == types.EventTouched && e.Actor == "alice" {
+			touched++
+		}
+	}
+	if touched != 1 {
+		t.Errorf("Expected 1 touched event by alice, got %d", touched)
+	}
+
+	if err := store.TouchIssue(ctx, "vc-9999", "alice"); err == nil {
+		t.Error("Expected error for non-existent issue")
+	}
+}
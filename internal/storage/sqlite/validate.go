@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// ValidateBatch checks proposed issues without writing anything, e.g. from a CI
+// pre-commit hook. Every issue is checked in full, as CreateIssue would see it,
+// and the result for each lists all of its failures. The returned error is only
+// for a canceled context, never for invalid issues.
+func (s *SQLiteStorage) ValidateBatch(ctx context.Context, issues []*types.Issue) ([]types.ValidationResult, error) {
+	results := make([]types.ValidationResult, 0, len(issues))
+	for i, issue := range issues {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result := types.ValidationResult{Index: i}
+		if issue == nil {
+			result.Errors = []string{"issue is nil"}
+			results = append(results, result)
+			continue
+		}
+
+		candidate := *issue
+		s.applyCreateDefaults(&candidate)
+		for _, err := range candidate.ValidationErrorsWithMaxPriority(s.maxPriority) {
+			result.Errors = append(result.Errors, err.Error())
+		}
+		result.OK = len(result.Errors) == 0
+		results = append(results, result)
+	}
+	return results, nil
+}
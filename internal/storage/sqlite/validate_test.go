@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestValidateBatch(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issues := []*types.Issue{
+		{Title: "Valid", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{Title: "", Status: "done", Priority: 9, IssueType: types.TypeTask},
+		nil,
+		{Title: "Also valid", Status: types.StatusBlocked, Priority: 0, IssueType: types.TypeBug},
+		{Title: "Bad type", Status: types.StatusOpen, Priority: 2, IssueType: "story"},
+	}
+
+	results, err := store.ValidateBatch(ctx, issues)
+	if err != nil {
+		t.Fatalf("ValidateBatch failed: %v", err)
+	}
+	if len(results) != len(issues) {
+		t.Fatalf("Expected %d results, got %d", len(issues), len(results))
+	}
+
+	wantErrors := []int{0, 3, 1, 0, 1}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("Result %d: expected index %d, got %d", i, i, result.Index)
+		}
+		if result.OK != (wantErrors[i] == 0) || len(result.Errors) != wantErrors[i] {
+			t.Errorf("Result %d: expected %d errors, got ok=%v errors=%v", i, wantErrors[i], result.OK, result.Errors)
+		}
+	}
+
+	// Nothing was written
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("Expected no issues to be created, got %d", len(all))
+	}
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// GetIssueTracked retrieves an issue like GetIssue and records that actor viewed it.
+// Plain GetIssue never records views, so reads stay write-free unless callers opt in.
+func (s *SQLiteStorage) GetIssueTracked(ctx context.Context, id, actor string) (*types.Issue, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil || issue == nil {
+		return issue, err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, created_at)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventViewed, actor, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to record view: %w", err)
+	}
+
+	return issue, nil
+}
+
+// GetRecentlyViewed returns the issues actor most recently viewed through
+// GetIssueTracked, newest first. Each issue appears once, at its latest view.
+func (s *SQLiteStorage) GetRecentlyViewed(ctx context.Context, actor string, limit int) ([]*types.Issue, error) {
+	limitSQL := ""
+	if limit > 0 {
+		limitSQL = fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	// Event IDs increase with insertion, so the highest ID is the latest view
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+issueColumns+`
+		FROM issues i
+		JOIN (
+			SELECT e.issue_id, MAX(e.id) AS last_view
+			FROM events e
+			WHERE e.actor = ? AND e.event_type = ?
+			GROUP BY e.issue_id
+		) viewed ON viewed.issue_id = i.id
+		WHERE (? = '' OR i.project_id = ?)
+		ORDER BY viewed.last_view DESC
+	`+limitSQL, actor, types.EventViewed, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recently viewed issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(rows)
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestGetRecentlyViewed(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"A", "B", "C"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	// Untracked reads don't count as views
+	if _, err := store.GetIssue(ctx, ids[2]); err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	for _, view := range []struct{ id, actor string }{
+		{ids[0], "alice"},
+		{ids[1], "alice"},
+		{ids[0], "alice"},
+		{ids[2], "bob"},
+	} {
+		issue, err := store.GetIssueTracked(ctx, view.id, view.actor)
+		if err != nil || issue == nil || issue.ID != view.id {
+			t.Fatalf("GetIssueTracked(%s) = %v, %v", view.id, issue, err)
+		}
+	}
+
+	viewed, err := store.GetRecentlyViewed(ctx, "alice", 10)
+	if err != nil {
+		t.Fatalf("GetRecentlyViewed failed: %v", err)
+	}
+	if len(viewed) != 2 || viewed[0].ID != ids[0] || viewed[1].ID != ids[1] {
+		t.Fatalf("Expected [%s %s], got %d issues", ids[0], ids[1], len(viewed))
+	}
+
+	viewed, _ = store.GetRecentlyViewed(ctx, "alice", 1)
+	if len(viewed) != 1 || viewed[0].ID != ids[0] {
+		t.Errorf("Expected limit to keep only the latest view")
+	}
+
+	// Missing issues return nil without recording anything
+	issue, err := store.GetIssueTracked(ctx, "vc-9999", "alice")
+	if err != nil || issue != nil {
+		t.Errorf("Expected nil issue for missing ID, got %v, %v", issue, err)
+	}
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// Vote records a vote for an issue. Voting twice for the same issue is a no-op.
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) Vote(ctx context.Context, issueID, voter string) error {
+	if strings.TrimSpace(voter) == "" {
+		return types.NewValidationError("voter", voter, types.ErrInvalidField, "voter is required")
+	}
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO votes (issue_id, voter)
+		VALUES (?, ?)
+	`, issueID, voter)
+	if err != nil {
+		return fmt.Errorf("failed to vote for issue %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// Unvote removes a voter's vote from an issue. Removing a missing vote is a no-op.
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) Unvote(ctx context.Context, issueID, voter string) error {
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		DELETE FROM votes WHERE issue_id = ? AND voter = ?
+	`, issueID, voter)
+	if err != nil {
+		return fmt.Errorf("failed to remove vote from issue %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// GetVoteCount returns the number of distinct voters for an issue. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) GetVoteCount(ctx context.Context, issueID string) (int, error) {
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	var count int
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM votes WHERE issue_id = ?
+	`, issueID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count votes: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestVoteAndUnvote(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Popular request", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	for _, voter := range []string{"alice", "bob", "alice"} {
+		if err := store.Vote(ctx, issue.ID, voter); err != nil {
+			t.Fatalf("Vote by %s failed: %v", voter, err)
+		}
+	}
+
+	count, err := store.GetVoteCount(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetVoteCount failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected double vote to be suppressed (2 votes), got %d", count)
+	}
+
+	if err := store.Unvote(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Unvote failed: %v", err)
+	}
+	count, _ = store.GetVoteCount(ctx, issue.ID)
+	if count != 1 {
+		t.Errorf("Expected 1 vote after unvote, got %d", count)
+	}
+
+	if err := store.Vote(ctx, issue.ID, ""); err == nil {
+		t.Error("Expected error for empty voter")
+	}
+	if err := store.Vote(ctx, "vc-9999", "alice"); err == nil {
+		t.Error("Expected error voting for non-existent issue")
+	}
+}
+
+func TestSearchIssuesSortByVotes(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	// Priorities are chosen so the default order is the reverse of the vote order
+	var ids []string
+	for i, title := range []string{"Few votes", "Some votes", "Most votes"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: i, IssueType: types.TypeFeature}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		for v := 0; v <= i; v++ {
+			if err := store.Vote(ctx, issue.ID, string(rune('a'+v))); err != nil {
+				t.Fatalf("Vote failed: %v", err)
+			}
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: types.SortByVotes})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, want := range []string{ids[2], ids[1], ids[0]} {
+		if results[i].ID != want {
+			t.Errorf("Result %d: expected %s, got %s", i, want, results[i].ID)
+		}
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: "bogus"}); err == nil {
+		t.Error("Expected error for invalid sort field")
+	}
+}
+
+func TestVotesCascadeDelete(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Doomed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := store.Vote(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Vote failed: %v", err)
+	}
+
+	if _, err := store.db.Exec("DELETE FROM issues WHERE id = ?", issue.ID); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+
+	var count int
+	if err := store.db.QueryRow("SELECT COUNT(*) FROM votes WHERE issue_id = ?", issue.ID).Scan(&count); err != nil {
+		t.Fatalf("Failed to count votes: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected votes to be cascade deleted, found %d", count)
+	}
+}
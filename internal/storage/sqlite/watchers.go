@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// AddWatcher subscribes user to notifications about an issue (see
+// Hooks.OnWatchersNotify). Watching an issue twice is a no-op. Returns
+// ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) AddWatcher(ctx context.Context, issueID, user string) error {
+	if strings.TrimSpace(user) == "" {
+		return types.NewValidationError("user", user, types.ErrInvalidField, "user is required")
+	}
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO watchers (issue_id, user, created_at)
+		VALUES (?, ?, ?)
+	`, issueID, user, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to add watcher to issue %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// RemoveWatcher unsubscribes user from an issue. Removing a missing watcher is a no-op.
+// Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) RemoveWatcher(ctx context.Context, issueID, user string) error {
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		DELETE FROM watchers WHERE issue_id = ? AND user = ?
+	`, issueID, user)
+	if err != nil {
+		return fmt.Errorf("failed to remove watcher from issue %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// GetWatchers returns the users watching an issue, in the order they started
+// watching. Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) GetWatchers(ctx context.Context, issueID string) ([]string, error) {
+	exists, err := s.issueExists(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT user FROM watchers
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, user ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var watchers []string
+	for rows.Next() {
+		var user string
+		if err := rows.Scan(&user); err != nil {
+			return nil, fmt.Errorf("failed to scan watcher: %w", err)
+		}
+		watchers = append(watchers, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating watchers: %w", err)
+	}
+	return watchers, nil
+}
+
+// notifyWatchers passes an issue's watchers to Hooks.OnWatchersNotify after a
+// committed change. The change already succeeded, so failing to look up the
+// watchers is only logged.
+func (s *SQLiteStorage) notifyWatchers(ctx context.Context, issueID string, eventType types.EventType, actor string) {
+	hook := s.opts.hooks.OnWatchersNotify
+	if hook == nil {
+		return
+	}
+	watchers, err := s.GetWatchers(ctx, issueID)
+	if err != nil {
+		s.opts.warnf("failed to notify watchers of %s: %v", issueID, err)
+		return
+	}
+	if len(watchers) > 0 {
+		hook(ctx, issueID, watchers, eventType, actor)
+	}
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestWatchers(t *testing.T) {
+	var notified []string
+	store := setupTestDB(t, WithHooks(Hooks{
+		OnWatchersNotify: func(ctx context.Context, id string, watchers []string, eventType types.EventType, actor string) {
+			notified = append(notified, string(eventType)+":"+strings.Join(watchers, ","))
+		},
+	}))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Nobody watches yet, so updates notify no one
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if len(notified) != 0 {
+		t.Errorf("Expected no notifications for an unwatched issue, got %v", notified)
+	}
+
+	for _, user := range []string{"alice", "bob", "alice"} {
+		if err := store.AddWatcher(ctx, issue.ID, user); err != nil {
+			t.Fatalf("AddWatcher failed: %v", err)
+		}
+	}
+	if err := store.RemoveWatcher(ctx, issue.ID, "bob"); err != nil {
+		t.Fatalf("RemoveWatcher failed: %v", err)
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	// Commenting adds the commenter as a watcher before notifying
+	if err := store.AddComment(ctx, issue.ID, "carol", "Looking into it"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	want := []string{"updated:alice", "commented:alice,carol"}
+	if strings.Join(notified, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected notifications %v, got %v", want, notified)
+	}
+
+	// Each change notifies with the event it recorded
+	notified = nil
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"start", func() error {
+			return store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test")
+		}},
+		{"block", func() error { return store.BlockIssue(ctx, issue.ID, "waiting", "test") }},
+		{"unblock", func() error { return store.UnblockIssue(ctx, issue.ID, "test") }},
+		{"close via update", func() error {
+			return store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusClosed)}, "test")
+		}},
+		{"reopen", func() error { return store.ReopenIssue(ctx, issue.ID, "again", "test") }},
+		{"close", func() error { return store.CloseIssue(ctx, issue.ID, "done", "test") }},
+		{"archive", func() error { return store.ArchiveIssue(ctx, issue.ID, "test") }},
+		{"unarchive", func() error { return store.UnarchiveIssue(ctx, issue.ID, "test") }},
+		{"approve", func() error { return store.ApproveIssue(ctx, issue.ID, "test") }},
+		{"unapprove", func() error { return store.UnapproveIssue(ctx, issue.ID, "test") }},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s failed: %v", step.name, err)
+		}
+	}
+	want = []string{
+		"status_changed:alice,carol", "status_changed:alice,carol", "status_changed:alice,carol",
+		"closed:alice,carol", "reopened:alice,carol", "closed:alice,carol",
+		"archived:alice,carol", "unarchived:alice,carol", "approved:alice,carol", "unapproved:alice,carol",
+	}
+	if strings.Join(notified, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected notifications %v, got %v", want, notified)
+	}
+
+	watchers, err := store.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if strings.Join(watchers, ",") != "alice,carol" {
+		t.Errorf("Expected watchers alice,carol, got %v", watchers)
+	}
+
+	if err := store.AddWatcher(ctx, "vc-9999", "alice"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+	if err := store.AddWatcher(ctx, issue.ID, " "); err == nil {
+		t.Error("Expected error for empty user")
+	}
+}
+
+func TestAutoWatchOnCommentDisabled(t *testing.T) {
+	store := setupTestDB(t, WithAutoWatchOnComment(false))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Quiet", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "carol", "Drive-by"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	watchers, err := store.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if len(watchers) != 0 {
+		t.Errorf("Expected no watchers, got %v", watchers)
+	}
+}
+
+func TestWatchersNotifiedOfRecordedEvents(t *testing.T) {
+	var notified []string
+	store := setupTestDB(t, WithUnauditedFields("notes"), WithHooks(Hooks{
+		OnWatchersNotify: func(ctx context.Context, id string, watchers []string, eventType types.EventType, actor string) {
+			notified = append(notified, string(eventType))
+		},
+	}))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Handoff", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddWatcher(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		updates map[string]interface{}
+		want    string
+	}{
+		{"assignee only", map[string]interface{}{"assignee": "bob"}, "assigned"},
+		{"title and assignee", map[string]interface{}{"title": "Handoff again", "assignee": "carol"}, "updated assigned"},
+		{"unaudited only", map[string]interface{}{"notes": "scratch"}, ""},
+	} {
+		notified = nil
+		if err := store.UpdateIssue(ctx, issue.ID, tc.updates, "test"); err != nil {
+			t.Fatalf("%s: UpdateIssue failed: %v", tc.name, err)
+		}
+		if got := strings.Join(notified, " "); got != tc.want {
+			t.Errorf("%s: expected notifications %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// wipLimitConfigPrefix prefixes per-assignee WIP limit keys in the config table
+const wipLimitConfigPrefix = "wip_limit."
+
+// queryRower is implemented by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SetAssigneeWIPLimit overrides the WIP limit for one assignee; 0 removes their limit
+// even when a global limit is set with WithWIPLimit
+func (s *SQLiteStorage) SetAssigneeWIPLimit(ctx context.Context, assignee string, limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("WIP limit must not be negative (got %d)", limit)
+	}
+	return s.SetConfig(ctx, wipLimitConfigPrefix+assignee, strconv.Itoa(limit))
+}
+
+// checkWIPLimitForUpdate applies the WIP limit to an UpdateIssue that leaves the
+// issue in_progress with a different status or assignee than before. q should be the
+// update's transaction.
+func (s *SQLiteStorage) checkWIPLimitForUpdate(ctx context.Context, q queryRower, oldIssue *types.Issue, updates map[string]interface{}) error {
+	status := oldIssue.Status
+	switch v := updates["status"].(type) {
+	case string:
+		status = types.Status(v)
+	case types.Status:
+		status = v
+	}
+	assignee := oldIssue.Assignee
+	if v, ok := updates["assignee"]; ok {
+		assignee, _ = v.(string)
+	}
+
+	if status != types.StatusInProgress {
+		return nil
+	}
+	if oldIssue.Status == types.StatusInProgress && assignee == oldIssue.Assignee {
+		return nil
+	}
+	return s.checkWIPLimit(ctx, q, oldIssue.ID, assignee)
+}
+
+// checkWIPLimit returns ErrWIPLimitExceeded if assignee already holds their limit of
+// in_progress issues, not counting issueID itself. Unassigned work is never limited.
+func (s *SQLiteStorage) checkWIPLimit(ctx context.Context, q queryRower, issueID, assignee string) error {
+	if strings.TrimSpace(assignee) == "" {
+		return nil
+	}
+
+	limit := s.opts.wipLimit
+	var override string
+	err := q.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, wipLimitConfigPrefix+assignee).Scan(&override)
+	switch {
+	case err == nil:
+		if limit, err = strconv.Atoi(override); err != nil {
+			return fmt.Errorf("invalid WIP limit for %s: %q", assignee, override)
+		}
+	case err != sql.ErrNoRows:
+		return fmt.Errorf("failed to read WIP limit: %w", err)
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	var inProgress int
+	err = q.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM issues
+		WHERE assignee = ? AND status = ? AND id != ?
+	`, assignee, types.StatusInProgress, issueID).Scan(&inProgress)
+	if err != nil {
+		return fmt.Errorf("failed to count in-progress issues: %w", err)
+	}
+	if inProgress >= limit {
+		return fmt.Errorf("%s has %d in-progress issues (limit %d): %w", assignee, inProgress, limit, ErrWIPLimitExceeded)
+	}
+	return nil
+}
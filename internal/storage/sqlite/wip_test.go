@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestWIPLimitUpdateIssue(t *testing.T) {
+	store := setupTestDB(t, WithWIPLimit(2))
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for i := 0; i < 4; i++ {
+		issue := &types.Issue{Title: "Work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	start := map[string]interface{}{"status": string(types.StatusInProgress), "assignee": "alice"}
+	for _, issue := range issues[:2] {
+		if err := store.UpdateIssue(ctx, issue.ID, start, "test"); err != nil {
+			t.Fatalf("UpdateIssue within limit failed: %v", err)
+		}
+	}
+
+	err := store.UpdateIssue(ctx, issues[2].ID, start, "test")
+	if !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Fatalf("Expected ErrWIPLimitExceeded, got %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issues[2].ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("Expected rejected update to leave the issue untouched, got %s/%q", got.Status, got.Assignee)
+	}
+
+	// Updating an issue alice already holds doesn't count against her
+	if err := store.UpdateIssue(ctx, issues[0].ID, map[string]interface{}{"priority": 1}, "test"); err != nil {
+		t.Errorf("Expected update of held issue to succeed, got %v", err)
+	}
+
+	// Only in_progress counts: assigning open work is fine
+	if err := store.UpdateIssue(ctx, issues[2].ID, map[string]interface{}{"assignee": "alice"}, "test"); err != nil {
+		t.Errorf("Expected assigning open work to succeed, got %v", err)
+	}
+
+	// A per-assignee override takes precedence over the global limit
+	if err := store.SetAssigneeWIPLimit(ctx, "alice", 3); err != nil {
+		t.Fatalf("SetAssigneeWIPLimit failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issues[2].ID, start, "test"); err != nil {
+		t.Errorf("Expected raised limit to allow a third issue, got %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issues[3].ID, start, "test"); !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Errorf("Expected ErrWIPLimitExceeded at the raised limit, got %v", err)
+	}
+}
+
+func TestWIPLimitClaimIssue(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	// No global limit; only bob is limited
+	if err := store.SetAssigneeWIPLimit(ctx, "bob", 1); err != nil {
+		t.Fatalf("SetAssigneeWIPLimit failed: %v", err)
+	}
+
+	now := time.Now()
+	executor := &types.ExecutorInstance{
+		InstanceID: "executor-1", Hostname: "test-host", PID: 1,
+		Status: types.ExecutorStatusRunning, StartedAt: now, LastHeartbeat: now,
+		Version: "0.1.0", Metadata: `{}`,
+	}
+	if err := store.RegisterInstance(ctx, executor); err != nil {
+		t.Fatalf("RegisterInstance failed: %v", err)
+	}
+
+	var issues []*types.Issue
+	for _, assignee := range []string{"bob", "bob", "carol", "carol"} {
+		issue := &types.Issue{Title: "Work", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	if err := store.ClaimIssue(ctx, issues[0].ID, executor.InstanceID); err != nil {
+		t.Fatalf("ClaimIssue failed: %v", err)
+	}
+	if err := store.ClaimIssue(ctx, issues[1].ID, executor.InstanceID); !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Errorf("Expected ErrWIPLimitExceeded claiming bob's second issue, got %v", err)
+	}
+	for _, issue := range issues[2:] {
+		if err := store.ClaimIssue(ctx, issue.ID, executor.InstanceID); err != nil {
+			t.Errorf("Expected unlimited assignee to claim freely, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+// LogWork records minutes of work on an issue by actor, with an optional note, and
+// an EventWorkLogged so the time shows up in the issue's timeline. Minutes must be
+// positive. Returns ErrIssueNotFound if the issue doesn't exist.
+func (s *SQLiteStorage) LogWork(ctx context.Context, issueID string, minutes int, actor, note string) error {
+	if minutes <= 0 {
+		return types.NewValidationError("minutes", minutes, types.ErrInvalidField, "minutes must be positive, got %d", minutes)
+	}
+	issue, err := s.getIssue(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", issueID, ErrIssueNotFound)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO worklog (issue_id, minutes, actor, note, logged_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, issueID, minutes, actor, note, now)
+	if err != nil {
+		return fmt.Errorf("failed to log work: %w", err)
+	}
+
+	newData, err := json.Marshal(map[string]int{"minutes": minutes})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	comment := note
+	if comment == "" {
+		comment = fmt.Sprintf("Logged %d minutes", minutes)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value, comment, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, issueID, types.EventWorkLogged, actor, string(newData), comment, now)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetLoggedMinutes returns the total minutes logged against an issue, 0 if none
+func (s *SQLiteStorage) GetLoggedMinutes(ctx context.Context, issueID string) (int, error) {
+	var total int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(minutes), 0) FROM worklog WHERE issue_id = ?
+	`, issueID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get logged minutes: %w", err)
+	}
+	return total, nil
+}
+
+// GetTimeReport compares estimated with logged minutes for every issue that has time
+// logged against it, biggest overrun first. Issues without an estimate come after
+// the estimated ones, most logged time first.
+func (s *SQLiteStorage) GetTimeReport(ctx context.Context) ([]*types.TimeReport, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.title, i.estimated_minutes, w.logged
+		FROM issues i
+		JOIN (
+			SELECT issue_id, SUM(minutes) AS logged FROM worklog GROUP BY issue_id
+		) w ON w.issue_id = i.id
+		WHERE (? = '' OR i.project_id = ?)
+		ORDER BY i.estimated_minutes IS NULL, w.logged - COALESCE(i.estimated_minutes, 0) DESC, i.id
+	`, s.opts.project, s.opts.project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time report: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var reports []*types.TimeReport
+	for rows.Next() {
+		var r types.TimeReport
+		var estimate sql.NullInt64
+		if err := rows.Scan(&r.IssueID, &r.Title, &estimate, &r.LoggedMinutes); err != nil {
+			return nil, fmt.Errorf("failed to scan time report: %w", err)
+		}
+		r.EstimatedMinutes = nullIntPtr(estimate)
+		reports = append(reports, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating time report: %w", err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/vc/internal/types"
+)
+
+func TestWorklog(t *testing.T) {
+	store := setupTestDB(t)
+	ctx := context.Background()
+
+	estimate := 60
+	over := &types.Issue{Title: "Overran", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: &estimate}
+	under := &types.Issue{Title: "Finished early", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, EstimatedMinutes: &estimate}
+	unestimated := &types.Issue{Title: "No estimate", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	idle := &types.Issue{Title: "Nothing logged", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{over, under, unestimated, idle} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	for _, entry := range []struct {
+		issue   *types.Issue
+		minutes int
+		note    string
+	}{
+		{over, 45, "first pass"},
+		{over, 50, ""},
+		{under, 30, "quick fix"},
+		{unestimated, 20, ""},
+	} {
+		if err := store.LogWork(ctx, entry.issue.ID, entry.minutes, "alice", entry.note); err != nil {
+			t.Fatalf("LogWork failed: %v", err)
+		}
+	}
+
+	for _, minutes := range []int{0, -5} {
+		if err := store.LogWork(ctx, over.ID, minutes, "alice", ""); err == nil {
+			t.Errorf("expected %d minutes to be rejected", minutes)
+		}
+	}
+	if err := store.LogWork(ctx, "vc-999", 10, "alice", ""); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("expected ErrIssueNotFound, got %v", err)
+	}
+
+	logged, err := store.GetLoggedMinutes(ctx, over.ID)
+	if err != nil {
+		t.Fatalf("GetLoggedMinutes failed: %v", err)
+	}
+	if logged != 95 {
+		t.Errorf("logged = %d, want 95", logged)
+	}
+	if logged, _ := store.GetLoggedMinutes(ctx, idle.ID); logged != 0 {
+		t.Errorf("logged on idle issue = %d, want 0", logged)
+	}
+
+	// Each entry is in the timeline
+	events, err := store.GetEvents(ctx, over.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var notes []string
+	for _, e := range events {
+		if e.EventType == types.EventWorkLogged && e.Comment != nil {
+			notes = append(notes, *e.Comment)
+		}
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 work events, got %v", notes)
+	}
+	for _, want := range []string{"first pass", "Logged 50 minutes"} {
+		if notes[0] != want && notes[1] != want {
+			t.Errorf("missing work event %q in %v", want, notes)
+		}
+	}
+
+	report, err := store.GetTimeReport(ctx)
+	if err != nil {
+		t.Fatalf("GetTimeReport failed: %v", err)
+	}
+	want := []struct {
+		id     string
+		logged int
+	}{{over.ID, 95}, {under.ID, 30}, {unestimated.ID, 20}}
+	if len(report) != len(want) {
+		t.Fatalf("got %d report rows, want %d", len(report), len(want))
+	}
+	for i, w := range want {
+		if report[i].IssueID != w.id || report[i].LoggedMinutes != w.logged {
+			t.Errorf("report[%d] = %s with %d minutes, want %s with %d", i, report[i].IssueID, report[i].LoggedMinutes, w.id, w.logged)
+		}
+	}
+	if report[0].EstimatedMinutes == nil || *report[0].EstimatedMinutes != 60 || report[2].EstimatedMinutes != nil {
+		t.Error("expected estimates to be reported as stored")
+	}
+}
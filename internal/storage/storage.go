@@ -2,7 +2,10 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"io"
 	"os"
+	"time"
 
 	"github.com/steveyegge/vc/internal/events"
 	"github.com/steveyegge/vc/internal/storage/sqlite"
@@ -32,13 +35,9 @@ type Storage interface {
 	GetEventCounts(ctx context.Context) (*sqlite.EventCounts, error)
 	VacuumDatabase(ctx context.Context) error
 
-	// Issues
-	CreateIssue(ctx context.Context, issue *types.Issue, actor string) error
-	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	// Issues (CreateIssue, GetIssue, UpdateIssue, CloseIssue, SearchIssues via IssueStore)
+	IssueStore
 	GetMission(ctx context.Context, id string) (*types.Mission, error)
-	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
-	CloseIssue(ctx context.Context, id string, reason string, actor string) error
-	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
 
 	// Dependencies
 	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
@@ -59,9 +58,7 @@ type Storage interface {
 	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
 	GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error)
 
-	// Events
-	AddComment(ctx context.Context, issueID, actor, comment string) error
-	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
+	// Events (AddComment, GetEvents via IssueStore)
 
 	// Statistics
 	GetStatistics(ctx context.Context) (*types.Statistics, error)
@@ -90,11 +87,213 @@ type Storage interface {
 	// Config
 	GetConfig(ctx context.Context, key string) (string, error)
 	SetConfig(ctx context.Context, key, value string) error
+}
+
+// ExtendedStorage is Storage plus every feature interface below. The SQLite backend
+// implements all of it; other backends, such as the Beads wrapper, implement only
+// Storage, so code that needs a feature should accept or type-assert the narrowest
+// interface that has it, e.g. store.(storage.CommentStore).
+type ExtendedStorage interface {
+	Storage
+	IssueLifecycleStore
+	IssueQueryStore
+	RelationshipStore
+	HistoryStore
+	CommentStore
+	WatcherStore
+	VoteStore
+	ReminderStore
+	FlagStore
+	ChecklistStore
+	AliasStore
+	WorklogStore
+	ReportStore
+	TransferStore
+	AdminStore
+}
+
+// IssueStore is the core issue tracking contract shared by every backend: issue
+// CRUD, search, comments and the event history. The SQLite backend implements all
+// of Storage; internal/storage/memory implements just this, for tests that don't
+// need a database. Both must pass the conformance suite in conformance_test.go.
+type IssueStore interface {
+	CreateIssue(ctx context.Context, issue *types.Issue, actor string) error
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+	CloseIssue(ctx context.Context, id string, reason string, actor string) error
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+
+	AddComment(ctx context.Context, issueID, actor, comment string) error
+	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
 
-	// Lifecycle
 	Close() error
 }
 
+// IssueLifecycleStore creates issues in bulk or from other sources, and moves them
+// through states beyond open and closed
+type IssueLifecycleStore interface {
+	CreateIssueWithOptions(ctx context.Context, issue *types.Issue, opts types.CreateOptions, actor string) error
+	CreateIssues(ctx context.Context, issues []*types.Issue, actor string) ([]string, error)
+	CreateIssueAutoAssign(ctx context.Context, issue *types.Issue, actor string) error
+	CreateIssueFromTemplate(ctx context.Context, tmpl *types.IssueTemplate, assignee string, actor string) (*types.Issue, error)
+	CloneIssue(ctx context.Context, sourceID string, opts types.CloneOptions, actor string) (*types.Issue, error)
+	ValidateBatch(ctx context.Context, issues []*types.Issue) ([]types.ValidationResult, error)
+
+	UpdateIssueChanged(ctx context.Context, id string, updates map[string]interface{}, actor string) (bool, error)
+	UpdateIssueIfUnchanged(ctx context.Context, id string, updates map[string]interface{}, expectedUpdatedAt time.Time, actor string) error
+	ReopenIssue(ctx context.Context, id string, reason string, actor string) error
+	TouchIssue(ctx context.Context, id, actor string) error
+	RecomputePriorities(ctx context.Context, rule types.PriorityRule, actor string) (int, error)
+	SetAssigneeWIPLimit(ctx context.Context, assignee string, limit int) error
+
+	DeleteIssue(ctx context.Context, id string, actor string) error
+	DeleteIssueWithOptions(ctx context.Context, id string, opts types.DeleteOptions, actor string) error
+	GetDeletedIssueEvents(ctx context.Context, issueID string) ([]*types.Event, error)
+
+	ArchiveIssue(ctx context.Context, id, actor string) error
+	UnarchiveIssue(ctx context.Context, id, actor string) error
+	PinIssue(ctx context.Context, id string) error
+	UnpinIssue(ctx context.Context, id string) error
+	BlockIssue(ctx context.Context, id string, reason string, actor string) error
+	UnblockIssue(ctx context.Context, id string, actor string) error
+	ApproveIssue(ctx context.Context, id, approver string) error
+	UnapproveIssue(ctx context.Context, id, actor string) error
+	RequestReview(ctx context.Context, id, reviewer, actor string) error
+	GetReviewQueue(ctx context.Context, reviewer string) ([]*types.Issue, error)
+}
+
+// IssueQueryStore finds issues: paginated and streamed search, counts, ID
+// resolution and lookups by relatedness
+type IssueQueryStore interface {
+	ListIssues(ctx context.Context, filter types.IssueFilter, cursor string, pageSize int) (issues []*types.Issue, nextCursor string, err error)
+	SearchIssuesPage(ctx context.Context, query string, filter types.IssueFilter) (*types.IssuePage, error)
+	SearchIssuesWithCounts(ctx context.Context, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error)
+	SearchMulti(ctx context.Context, queries map[string]types.IssueFilter) (map[string][]*types.Issue, error)
+	CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int, error)
+	IterateIssues(ctx context.Context, query string, filter types.IssueFilter, fn func(*types.Issue) error) error
+
+	ResolveID(ctx context.Context, ref string) (string, error)
+	GetIssueETag(ctx context.Context, id string) (string, error)
+	GetIssueTracked(ctx context.Context, id, actor string) (*types.Issue, error)
+	GetRecentlyViewed(ctx context.Context, actor string, limit int) ([]*types.Issue, error)
+	GetIssuesModifiedBy(ctx context.Context, actor string, since time.Time) ([]*types.Issue, error)
+	GetIssuesByCriteriaProgress(ctx context.Context, maxPercent float64) ([]*types.Issue, error)
+
+	FindReferences(ctx context.Context, id string) ([]*types.Issue, error)
+	FindSimilarIssues(ctx context.Context, id string, threshold float64) ([]*types.Issue, error)
+	GetRelatedByLabels(ctx context.Context, id string, minShared int) ([]*types.Issue, error)
+	GetLabelCounts(ctx context.Context) (map[string]int, error)
+}
+
+// RelationshipStore navigates and maintains the dependency graph beyond the
+// basic dependency operations in Storage
+type RelationshipStore interface {
+	GetBlockers(ctx context.Context, issueID string) ([]*types.Issue, error)
+	GetBlocked(ctx context.Context, issueID string) ([]*types.Issue, error)
+	GetImpact(ctx context.Context, id string) ([]*types.Issue, error)
+	GetChildren(ctx context.Context, parentID string) ([]*types.Issue, error)
+	SetParent(ctx context.Context, childID, parentID, actor string) error
+	GetEstimateRollup(ctx context.Context, parentID string) (*types.EstimateRollup, error)
+	RepairRelationships(ctx context.Context) (*types.RepairReport, error)
+}
+
+// HistoryStore records and reads back an issue's event history
+type HistoryStore interface {
+	RecordEvent(ctx context.Context, issueID string, eventType types.EventType, actor, comment string, oldValue, newValue interface{}) error
+	GetEventTimeline(ctx context.Context, issueID string) ([]*types.Event, error)
+	GetAssignmentHistory(ctx context.Context, issueID string) ([]*types.Assignment, error)
+	GetFieldMetadata(ctx context.Context, id string) (map[string]types.FieldMeta, error)
+	GetSLAElapsed(ctx context.Context, id string) (time.Duration, error)
+	GetTimeInStatus(ctx context.Context, id string) (map[types.Status]time.Duration, error)
+	ExportIssueHistory(ctx context.Context, id string, w io.Writer) error
+	BackfillClosedAt(ctx context.Context) (int, error)
+	BackfillCreationEvents(ctx context.Context, actor string) (int, error)
+}
+
+// CommentStore manages comments as editable records (AddComment is in IssueStore)
+type CommentStore interface {
+	AddCommentWithID(ctx context.Context, issueID, actor, body string) (string, error)
+	GetComments(ctx context.Context, issueID string) ([]*types.Comment, error)
+	EditComment(ctx context.Context, commentID, newBody, actor string) error
+}
+
+// WatcherStore manages the users notified about an issue
+type WatcherStore interface {
+	AddWatcher(ctx context.Context, issueID, user string) error
+	RemoveWatcher(ctx context.Context, issueID, user string) error
+	GetWatchers(ctx context.Context, issueID string) ([]string, error)
+}
+
+// VoteStore manages votes on issues
+type VoteStore interface {
+	Vote(ctx context.Context, issueID, voter string) error
+	Unvote(ctx context.Context, issueID, voter string) error
+	GetVoteCount(ctx context.Context, issueID string) (int, error)
+}
+
+// ReminderStore schedules reminders about issues for an external notifier
+type ReminderStore interface {
+	AddReminder(ctx context.Context, issueID string, remindAt time.Time, note, recipient string) (int64, error)
+	GetDueReminders(ctx context.Context, now time.Time) ([]*types.Reminder, error)
+	MarkReminderSent(ctx context.Context, reminderID int64) error
+}
+
+// FlagStore manages moderation and triage flags
+type FlagStore interface {
+	FlagIssue(ctx context.Context, issueID string, code types.FlagCode, note, flagger string) (int64, error)
+	ResolveFlag(ctx context.Context, flagID int64, actor string) error
+	GetFlags(ctx context.Context, issueID string) ([]*types.Flag, error)
+}
+
+// ChecklistStore manages issue checklists
+type ChecklistStore interface {
+	AddChecklistItem(ctx context.Context, issueID, text string) (int64, error)
+	SetChecklistItemDone(ctx context.Context, itemID int64, done bool) error
+	GetChecklist(ctx context.Context, issueID string) ([]*types.ChecklistItem, error)
+}
+
+// AliasStore manages other IDs an issue answers to
+type AliasStore interface {
+	AddIDAlias(ctx context.Context, issueID, alias string) error
+	GetIDAliases(ctx context.Context, issueID string) ([]string, error)
+}
+
+// WorklogStore records time spent on issues
+type WorklogStore interface {
+	LogWork(ctx context.Context, issueID string, minutes int, actor, note string) error
+	GetLoggedMinutes(ctx context.Context, issueID string) (int, error)
+	GetTimeReport(ctx context.Context) ([]*types.TimeReport, error)
+}
+
+// ReportStore computes reports across issues
+type ReportStore interface {
+	ExportMetrics(ctx context.Context) (*types.Metrics, error)
+	GetAgeDistribution(ctx context.Context, filter types.IssueFilter) (map[string]int, error)
+	GetEstimateAccuracy(ctx context.Context, from, to time.Time) (avgRatio float64, err error)
+	GetNeverTouchedIssues(ctx context.Context) ([]*types.Issue, error)
+	GetThinIssues(ctx context.Context, minDescriptionChars int) ([]*types.Issue, error)
+	GetThroughput(ctx context.Context, from, to time.Time) (closed int, created int, net int, err error)
+	GetWorkload(ctx context.Context) (map[string]int, error)
+}
+
+// TransferStore moves issues between trackers as bundles or JSON
+type TransferStore interface {
+	ExportBundle(ctx context.Context, w io.Writer) error
+	ImportBundle(ctx context.Context, r io.Reader, actor string) error
+	ImportBundleWithOptions(ctx context.Context, r io.Reader, opts types.ImportOptions, actor string) error
+	ExportNDJSON(ctx context.Context, w io.Writer, filter types.IssueFilter) error
+	ImportJSON(ctx context.Context, r io.Reader, actor string) (imported int, err error)
+	ImportJSONWithOptions(ctx context.Context, r io.Reader, opts types.ImportOptions, actor string) (imported int, err error)
+}
+
+// AdminStore reports on and configures the store itself
+type AdminStore interface {
+	CurrentSchemaVersion(ctx context.Context) (int, error)
+	GetStatusConfig(ctx context.Context) ([]*types.StatusConfig, error)
+	SetStatusConfig(ctx context.Context, cfg *types.StatusConfig) error
+	Stats() sql.DBStats
+}
+
 // Config holds database configuration
 type Config struct {
 	// Path is the SQLite database file path
@@ -135,5 +334,5 @@ func NewStorage(ctx context.Context, cfg *Config) (Storage, error) {
 		}
 	}
 
-	return sqlite.New(cfg.Path)
+	return Open("sqlite", cfg.Path)
 }
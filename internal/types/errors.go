@@ -0,0 +1,51 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for issue validation failures. Validation functions return them
+// wrapped in a *ValidationError, so callers can match with errors.Is and recover
+// the offending field and value with errors.As. Every ValidationError also matches
+// ErrValidation, for callers that only need to tell bad input from other failures.
+var (
+	ErrValidation          = errors.New("validation failed")
+	ErrTitleLength         = errors.New("title must be 1-500 characters")
+	ErrInvalidPriority     = errors.New("invalid priority")
+	ErrInvalidStatus       = errors.New("invalid status")
+	ErrInvalidIssueType    = errors.New("invalid issue type")
+	ErrInvalidIssueSubtype = errors.New("invalid issue subtype")
+	ErrInvalidEstimate     = errors.New("invalid estimate")
+	ErrInvalidField        = errors.New("invalid field")
+)
+
+// ValidationError describes a single field that failed validation
+type ValidationError struct {
+	Field string      // Field that failed validation (e.g. "priority")
+	Value interface{} // The rejected value
+	Err   error       // Sentinel describing the failure (e.g. ErrInvalidPriority)
+	msg   string
+}
+
+// NewValidationError returns a ValidationError for field with a formatted message
+func NewValidationError(field string, value interface{}, err error, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Field: field, Value: value, Err: err, msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *ValidationError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("%s: %s = %v", e.Err, e.Field, e.Value)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrValidation; errors.Is matches the specific
+// sentinel through Unwrap
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
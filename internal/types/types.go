@@ -20,6 +20,16 @@ type Issue struct {
 	IssueSubtype       IssueSubtype  `json:"issue_subtype,omitempty"` // mission, phase, or empty for normal issues
 	Assignee           string        `json:"assignee,omitempty"`
 	EstimatedMinutes   *int          `json:"estimated_minutes,omitempty"`
+	EstimateMinMinutes *int          `json:"estimate_min_minutes,omitempty"` // Optional lower bound of the estimate
+	EstimateMaxMinutes *int          `json:"estimate_max_minutes,omitempty"` // Optional upper bound of the estimate
+	ProjectID          string        `json:"project_id,omitempty"` // Owning project when the storage is project-scoped
+	Pinned             bool          `json:"pinned,omitempty"`     // Pinned issues are listed first in search results
+	BlockedReason      string        `json:"blocked_reason,omitempty"` // Why the issue is blocked on something outside the tracker
+	Reviewer           string        `json:"reviewer,omitempty"`       // Who is asked to review the issue (see RequestReview)
+	DueAt              *time.Time    `json:"due_at,omitempty"`         // When the work is due; see IssueFilter.Overdue
+	ArchivedAt         *time.Time    `json:"archived_at,omitempty"`    // Set while archived (hidden from searches); see ArchiveIssue
+	ApprovedAt         *time.Time    `json:"approved_at,omitempty"`    // When the issue was approved, if it has been
+	ApprovedBy         string        `json:"approved_by,omitempty"`    // Who approved the issue
 	CreatedAt          time.Time     `json:"created_at"`
 	UpdatedAt          time.Time     `json:"updated_at"`
 	ClosedAt           *time.Time    `json:"closed_at,omitempty"`
@@ -27,26 +37,87 @@ type Issue struct {
 
 // Validate checks if the issue has valid field values
 func (i *Issue) Validate() error {
+	return i.ValidateWithMaxPriority(DefaultMaxPriority)
+}
+
+// ValidateWithMaxPriority is Validate with priorities allowed up to maxPriority
+// instead of DefaultMaxPriority
+func (i *Issue) ValidateWithMaxPriority(maxPriority int) error {
+	if errs := i.ValidationErrorsWithMaxPriority(maxPriority); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidationErrors checks every field and returns all failures, in field order,
+// rather than stopping at the first like Validate
+func (i *Issue) ValidationErrors() []error {
+	return i.ValidationErrorsWithMaxPriority(DefaultMaxPriority)
+}
+
+// ValidationErrorsWithMaxPriority is ValidationErrors with priorities allowed up to
+// maxPriority instead of DefaultMaxPriority
+func (i *Issue) ValidationErrorsWithMaxPriority(maxPriority int) []error {
+	var errs []error
 	if len(i.Title) == 0 {
-		return fmt.Errorf("title is required")
+		errs = append(errs, NewValidationError("title", i.Title, ErrTitleLength, "title is required"))
 	}
 	if len(i.Title) > 500 {
-		return fmt.Errorf("title must be 500 characters or less (got %d)", len(i.Title))
+		errs = append(errs, NewValidationError("title", i.Title, ErrTitleLength, "title must be 500 characters or less (got %d)", len(i.Title)))
 	}
-	if i.Priority < 0 || i.Priority > 4 {
-		return fmt.Errorf("priority must be between 0 and 4 (got %d)", i.Priority)
+	if err := ValidatePriority(i.Priority, maxPriority); err != nil {
+		errs = append(errs, err)
 	}
 	if !i.Status.IsValid() {
-		return fmt.Errorf("invalid status: %s", i.Status)
+		errs = append(errs, NewValidationError("status", i.Status, ErrInvalidStatus, "invalid status: %s", i.Status))
 	}
 	if !i.IssueType.IsValid() {
-		return fmt.Errorf("invalid issue type: %s", i.IssueType)
+		errs = append(errs, NewValidationError("issue_type", i.IssueType, ErrInvalidIssueType, "invalid issue type: %s", i.IssueType))
 	}
 	if !i.IssueSubtype.IsValid() {
-		return fmt.Errorf("invalid issue subtype: %s", i.IssueSubtype)
+		errs = append(errs, NewValidationError("issue_subtype", i.IssueSubtype, ErrInvalidIssueSubtype, "invalid issue subtype: %s", i.IssueSubtype))
 	}
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
-		return fmt.Errorf("estimated_minutes cannot be negative")
+		errs = append(errs, NewValidationError("estimated_minutes", *i.EstimatedMinutes, ErrInvalidEstimate, "estimated_minutes cannot be negative"))
+	} else if err := ValidateEstimateRange(i.EstimatedMinutes, i.EstimateMinMinutes, i.EstimateMaxMinutes); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// DefaultMaxPriority is the lowest priority (highest number) allowed unless the
+// storage is configured with a wider range
+const DefaultMaxPriority = 4
+
+// PriorityUnset marks an issue created without a priority, so CreateIssue can apply a
+// per-type default (see sqlite.WithTypeDefaultPriorities). 0 can't mean unset: it's P0.
+const PriorityUnset = -1
+
+// ValidatePriority checks that priority is between 0 and maxPriority
+func ValidatePriority(priority, maxPriority int) error {
+	if priority < 0 || priority > maxPriority {
+		return NewValidationError("priority", priority, ErrInvalidPriority, "priority must be between 0 and %d (got %d)", maxPriority, priority)
+	}
+	return nil
+}
+
+// ValidateEstimateRange checks that the optional estimate bounds are non-negative
+// and ordered min <= point <= max for whichever values are present
+func ValidateEstimateRange(point, min, max *int) error {
+	if min != nil && *min < 0 {
+		return NewValidationError("estimate_min_minutes", *min, ErrInvalidEstimate, "estimate_min_minutes cannot be negative")
+	}
+	if max != nil && *max < 0 {
+		return NewValidationError("estimate_max_minutes", *max, ErrInvalidEstimate, "estimate_max_minutes cannot be negative")
+	}
+	if min != nil && max != nil && *min > *max {
+		return NewValidationError("estimate_min_minutes", *min, ErrInvalidEstimate, "estimate_min_minutes (%d) cannot exceed estimate_max_minutes (%d)", *min, *max)
+	}
+	if point != nil && min != nil && *point < *min {
+		return NewValidationError("estimated_minutes", *point, ErrInvalidEstimate, "estimated_minutes (%d) cannot be below estimate_min_minutes (%d)", *point, *min)
+	}
+	if point != nil && max != nil && *point > *max {
+		return NewValidationError("estimated_minutes", *point, ErrInvalidEstimate, "estimated_minutes (%d) cannot exceed estimate_max_minutes (%d)", *point, *max)
 	}
 	return nil
 }
@@ -70,6 +141,31 @@ func (s Status) IsValid() bool {
 	return false
 }
 
+// StatusCategory groups statuses into board columns
+type StatusCategory string
+
+const (
+	StatusCategoryTodo       StatusCategory = "todo"
+	StatusCategoryInProgress StatusCategory = "in-progress"
+	StatusCategoryDone       StatusCategory = "done"
+)
+
+// IsValid checks if the status category value is valid
+func (c StatusCategory) IsValid() bool {
+	switch c {
+	case StatusCategoryTodo, StatusCategoryInProgress, StatusCategoryDone:
+		return true
+	}
+	return false
+}
+
+// StatusConfig controls how a status is displayed on boards
+type StatusConfig struct {
+	Status       Status         `json:"status"`
+	DisplayOrder int            `json:"display_order"`
+	Category     StatusCategory `json:"category"`
+}
+
 // IssueType categorizes the kind of work
 type IssueType string
 
@@ -147,6 +243,63 @@ type Label struct {
 	Label   string `json:"label"`
 }
 
+// FlagCode identifies why an issue was flagged for moderation or triage
+type FlagCode string
+
+const (
+	FlagSpam        FlagCode = "spam"
+	FlagNeedsTriage FlagCode = "needs-triage"
+	FlagSecurity    FlagCode = "security"
+)
+
+// IsValid checks if the flag code value is valid
+func (c FlagCode) IsValid() bool {
+	switch c {
+	case FlagSpam, FlagNeedsTriage, FlagSecurity:
+		return true
+	}
+	return false
+}
+
+// Flag marks an issue for moderation or triage attention
+type Flag struct {
+	ID         int64      `json:"id"`
+	IssueID    string     `json:"issue_id"`
+	Code       FlagCode   `json:"code"`
+	Note       string     `json:"note,omitempty"`
+	Flagger    string     `json:"flagger"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy string     `json:"resolved_by,omitempty"`
+}
+
+// ChecklistItem is one item of an issue's checklist
+type ChecklistItem struct {
+	ID       int64  `json:"id"`
+	IssueID  string `json:"issue_id"`
+	Text     string `json:"text"`
+	Done     bool   `json:"done"`
+	Position int    `json:"position"`
+}
+
+// Reminder is a scheduled notification about an issue
+type Reminder struct {
+	ID        int64      `json:"id"`
+	IssueID   string     `json:"issue_id"`
+	RemindAt  time.Time  `json:"remind_at"`
+	Note      string     `json:"note,omitempty"`
+	Recipient string     `json:"recipient"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
+
+// TimeReport compares an issue's estimate with the time logged against it
+type TimeReport struct {
+	IssueID          string `json:"issue_id"`
+	Title            string `json:"title"`
+	EstimatedMinutes *int   `json:"estimated_minutes,omitempty"` // Nil if the issue has no estimate
+	LoggedMinutes    int    `json:"logged_minutes"`
+}
+
 // Event represents an audit trail entry
 type Event struct {
 	ID        int64      `json:"id"`
@@ -159,6 +312,33 @@ type Event struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+// Comment is a free-form note on an issue. Comments are also recorded as
+// EventCommented events, so they appear in the issue's timeline.
+type Comment struct {
+	ID        string     `json:"id"`
+	IssueID   string     `json:"issue_id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"` // Set by the most recent edit
+}
+
+// FieldMeta records when a mutable issue field was last changed and by whom.
+// It is derived from the events table rather than stored separately.
+type FieldMeta struct {
+	ModifiedAt time.Time `json:"modified_at"`
+	ModifiedBy string    `json:"modified_by"`
+	Unchanged  bool      `json:"unchanged"` // True if the field has not changed since creation
+}
+
+// Assignment is one entry in an issue's assignment history, derived from its events.
+// An empty Assignee means the issue was unassigned.
+type Assignment struct {
+	Assignee   string    `json:"assignee"`
+	AssignedAt time.Time `json:"assigned_at"`
+	AssignedBy string    `json:"assigned_by"`
+}
+
 // EventType categorizes audit trail events
 type EventType string
 
@@ -174,6 +354,16 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventWatchdog          EventType = "watchdog"
+	EventViewed            EventType = "viewed"  // Recorded only by GetIssueTracked
+	EventTouched           EventType = "touched" // Recorded by TouchIssue
+	EventDeleted           EventType = "deleted" // Recorded by DeleteIssue; kept in the deleted events archive
+	EventCommentEdited     EventType = "comment_edited"
+	EventWorkLogged        EventType = "work_logged" // Recorded by LogWork
+	EventAssigned          EventType = "assigned"    // Recorded by UpdateIssue when the assignee changes
+	EventArchived          EventType = "archived"    // Recorded by ArchiveIssue
+	EventUnarchived        EventType = "unarchived"  // Recorded by UnarchiveIssue
+	EventApproved          EventType = "approved"    // Recorded by ApproveIssue
+	EventUnapproved        EventType = "unapproved"  // Recorded by UnapproveIssue
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -183,6 +373,14 @@ type BlockedIssue struct {
 	BlockedBy      []string `json:"blocked_by"`
 }
 
+// IssueWithCounts is an issue with the relationship counts a backlog table shows as badges
+type IssueWithCounts struct {
+	Issue
+	BlockerCount int `json:"blocker_count"` // Open issues blocking this one
+	BlockedCount int `json:"blocked_count"` // Open issues this one blocks
+	ChildCount   int `json:"child_count"`   // Direct parent-child subtasks
+}
+
 // TreeNode represents a node in a dependency tree
 type TreeNode struct {
 	Issue
@@ -201,17 +399,185 @@ type Statistics struct {
 	AverageLeadTime  float64 `json:"average_lead_time_hours"`
 }
 
+// Metrics bundles the aggregate statistics a monitoring job scrapes in one call
+type Metrics struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Statistics  Statistics     `json:"statistics"`
+	Workload    map[string]int `json:"workload"`   // Non-closed issues per assignee; "" is unassigned
+	Throughput  Throughput     `json:"throughput"` // Over the MetricsThroughputWindow ending at GeneratedAt
+	Staleness   map[string]int `json:"staleness"`  // Non-closed issues by time since last update, in AgeBucket buckets
+}
+
+// MetricsThroughputWindow is the window Metrics reports throughput over
+const MetricsThroughputWindow = 7 * 24 * time.Hour
+
+// Throughput counts issues created and closed in the window [From, To)
+type Throughput struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Created int       `json:"created"`
+	Closed  int       `json:"closed"`
+	Net     int       `json:"net"` // Created - Closed
+}
+
+// EstimateRollup aggregates the estimates of an issue's subtasks, at any depth.
+// Subtasks without a range contribute their point estimate to both bounds.
+type EstimateRollup struct {
+	IssueID          string `json:"issue_id"`
+	Subtasks         int    `json:"subtasks"`
+	Unestimated      int    `json:"unestimated"` // Subtasks with no estimate of any kind
+	EstimatedMinutes int    `json:"estimated_minutes"`
+	MinMinutes       int    `json:"min_minutes"`
+	MaxMinutes       int    `json:"max_minutes"`
+}
+
+// RepairReport counts the dangling relationship rows RepairRelationships removed
+type RepairReport struct {
+	Dependencies int `json:"dependencies"` // blocks and discovered-from dependencies
+	Links        int `json:"links"`        // related links
+	ParentLinks  int `json:"parent_links"` // parent-child links
+}
+
+// Total returns the number of rows removed across all kinds
+func (r *RepairReport) Total() int {
+	return r.Dependencies + r.Links + r.ParentLinks
+}
+
+// CreateOptions adjusts how CreateIssueWithOptions creates an issue
+type CreateOptions struct {
+	AssignToReporter bool // Assign the issue to the creating actor, overriding any assignee set on it
+}
+
+// DeleteOptions controls what DeleteIssueWithOptions removes besides the issue itself
+type DeleteOptions struct {
+	// Purge also erases the issue's event history and agent events instead of
+	// archiving the history, for "right to be forgotten" requests. Nothing about
+	// the issue remains afterwards.
+	Purge bool
+}
+
+// ImportOptions adjusts how ImportBundleWithOptions and ImportJSONWithOptions import
+type ImportOptions struct {
+	// SkipImportEvents suppresses the per-issue "Imported from bundle" event, roughly
+	// halving the writes for large imports. The imported issues then have no record
+	// of the import in their own history; callers should record a summary instead.
+	SkipImportEvents bool
+
+	// DeferForeignKeys makes ImportBundleWithOptions check foreign keys once, after
+	// every row is written, instead of per row. Bundles whose rows reference each
+	// other load faster and in any order; the import still fails as a whole if any
+	// reference is left dangling.
+	DeferForeignKeys bool
+
+	// OnConflict decides what ImportJSONWithOptions does with an issue whose ID
+	// already exists; the zero value fails the import
+	OnConflict ConflictPolicy
+}
+
+// ConflictPolicy says how an import treats issues whose IDs already exist
+type ConflictPolicy string
+
+const (
+	ConflictError     ConflictPolicy = ""          // Fail the whole import
+	ConflictSkip      ConflictPolicy = "skip"      // Keep the existing issue
+	ConflictOverwrite ConflictPolicy = "overwrite" // Replace the existing issue's fields
+)
+
+// PriorityRule is a declarative triage rule for RecomputePriorities: open issues
+// matching every set condition get Priority. Zero-valued conditions match any issue.
+type PriorityRule struct {
+	OlderThan  time.Duration `json:"older_than,omitempty"` // Created at least this long ago
+	Unassigned bool          `json:"unassigned,omitempty"` // Has no assignee
+	Statuses   []Status      `json:"statuses,omitempty"`   // Status is one of these
+	IssueType  IssueType     `json:"issue_type,omitempty"` // Is of this type
+	Priority   int           `json:"priority"`             // Priority given to matching issues
+}
+
+// CloneOptions controls what CloneIssue copies besides the issue's fields
+type CloneOptions struct {
+	CopyLabels bool // Copy the source's labels
+	CopyLinks  bool // Copy the source's "related" links (never blocking or parent-child dependencies)
+}
+
+// IssueTemplate describes the content of issues created with CreateIssueFromTemplate.
+// Text fields may contain placeholders such as {{assignee}} or {{date}}, which are
+// substituted when an issue is created from the template.
+type IssueTemplate struct {
+	Title              string
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+	Priority           int
+	IssueType          IssueType
+	StrictPlaceholders bool // Fail on unknown placeholders instead of leaving them untouched
+}
+
+// ValidationResult is the outcome of validating one issue in a batch
+type ValidationResult struct {
+	Index  int      `json:"index"`            // Position of the issue in the batch
+	OK     bool     `json:"ok"`               // True if the issue passed every check
+	Errors []string `json:"errors,omitempty"` // Every failed check, not just the first
+}
+
+// Age buckets reported by GetAgeDistribution, measured back from the current time
+const (
+	AgeBucketToday     = "today"      // Created within the last 24 hours
+	AgeBucketThisWeek  = "this_week"  // 1 to 7 days old
+	AgeBucketThisMonth = "this_month" // 7 to 30 days old
+	AgeBucketOlder     = "older"      // More than 30 days old
+)
+
 // IssueFilter is used to filter issue queries
 type IssueFilter struct {
-	Status    *Status
-	Priority  *int
-	IssueType *IssueType
-	Type      *IssueType // Alias for IssueType (for compatibility)
-	Assignee  *string
-	Labels    []string
-	Limit     int
+	Status     *Status
+	Statuses   []Status // Any of these statuses; combined with Status if both are set
+	Priority   *int
+	Priorities []int // Any of these priorities; combined with Priority if both are set
+	IssueType  *IssueType
+	IssueTypes []IssueType // Any of these types; combined with IssueType if both are set
+	Type       *IssueType  // Alias for IssueType (for compatibility)
+	Assignee   *string
+	Reviewer   *string
+	Labels     []string
+	HasFlag    *string // Only issues with an unresolved flag of this code
+	Blocked    *bool   // Only blocked (true) or not blocked (false) issues
+	ParentID   *string // Only direct subtasks of this issue; "" matches top-level issues with no parent
+	Approved   *bool   // Only approved (true) or unapproved (false) issues
+
+	// Only issues with no blocks dependency on an issue that isn't closed: actionable work
+	NoOpenBlockers bool
+
+	// Only open (not closed) issues whose due_at has passed
+	Overdue bool
+
+	// Also match archived issues, which are otherwise excluded (see ArchiveIssue)
+	IncludeArchived bool
+
+	// Inclusive created_at and updated_at bounds; nil leaves that side open
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+
+	SortBy string // Optional sort order (e.g. SortByVotes); empty uses the default order
+	Limit  int
+	Offset int    // Skip this many matching issues (SearchIssues; SearchIssuesPage uses Cursor)
+	Cursor string // Resume SearchIssuesPage after the page that returned this NextCursor
+}
+
+// IssuePage is one page of SearchIssuesPage results
+type IssuePage struct {
+	Issues     []*Issue `json:"issues"`
+	NextCursor string   `json:"next_cursor,omitempty"` // Empty on the last page
 }
 
+// Sort orders for IssueFilter.SortBy
+const (
+	SortByVotes  = "votes"  // Vote count, most votes first
+	SortByStatus = "status" // Configured board column order (see StatusConfig)
+)
+
 // WorkFilter is used to filter ready work queries
 type WorkFilter struct {
 	Status   Status
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -145,3 +147,85 @@ func TestAllDependencyTypesValid(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateEstimateRange verifies min <= point <= max ordering for whichever values are set
+func TestValidateEstimateRange(t *testing.T) {
+	n := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		point   *int
+		min     *int
+		max     *int
+		wantErr bool
+	}{
+		{"no estimates", nil, nil, nil, false},
+		{"point only", n(30), nil, nil, false},
+		{"full ordered range", n(30), n(10), n(60), false},
+		{"range without point", nil, n(10), n(60), false},
+		{"point equals bounds", n(30), n(30), n(30), false},
+		{"min above max", nil, n(60), n(10), true},
+		{"point below min", n(5), n(10), nil, true},
+		{"point above max", n(90), nil, n(60), true},
+		{"negative min", nil, n(-1), nil, true},
+		{"negative max", nil, nil, n(-1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEstimateRange(tt.point, tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEstimateRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIssueValidateErrors(t *testing.T) {
+	valid := func() Issue {
+		return Issue{Title: "Valid", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	}
+	negative := -5
+	tests := []struct {
+		name   string
+		mutate func(*Issue)
+		field  string
+		want   error
+	}{
+		{"empty title", func(i *Issue) { i.Title = "" }, "title", ErrTitleLength},
+		{"long title", func(i *Issue) { i.Title = strings.Repeat("x", 501) }, "title", ErrTitleLength},
+		{"priority", func(i *Issue) { i.Priority = 7 }, "priority", ErrInvalidPriority},
+		{"status", func(i *Issue) { i.Status = "done" }, "status", ErrInvalidStatus},
+		{"issue type", func(i *Issue) { i.IssueType = "story" }, "issue_type", ErrInvalidIssueType},
+		{"issue subtype", func(i *Issue) { i.IssueSubtype = "saga" }, "issue_subtype", ErrInvalidIssueSubtype},
+		{"estimate", func(i *Issue) { i.EstimatedMinutes = &negative }, "estimated_minutes", ErrInvalidEstimate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := valid()
+			tt.mutate(&issue)
+			err := issue.Validate()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("Expected %v to match ErrValidation", err)
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Expected *ValidationError, got %T", err)
+			}
+			if verr.Field != tt.field {
+				t.Errorf("Expected field %q, got %q", tt.field, verr.Field)
+			}
+		})
+	}
+
+	issue := valid()
+	issue.Priority = 9
+	var verr *ValidationError
+	if err := issue.Validate(); !errors.As(err, &verr) || verr.Value != 9 {
+		t.Errorf("Expected offending value 9 in error, got %v", err)
+	}
+}